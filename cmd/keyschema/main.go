@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/support/fleet"
+	"github.com/brunoga/unitybridge/support/identity"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/wrapper"
+)
+
+var (
+	appID = flag.Uint64("appid", 0, "App ID of the robot to connect to. If 0, "+
+		"the app ID of the last robot seen is used (if any).")
+	output = flag.String("output", "", "File to save the inferred Go types "+
+		"to. If empty, they are printed to stdout.")
+	reportPath = flag.String("report", "", "Optional file to save the full "+
+		"JSON report (including raw values) to.")
+	known = flag.Bool("known", false, "Also generate types for keys that "+
+		"already have a value type (useful to check them).")
+	timeout = flag.Duration("timeout", 2*time.Second, "Time to wait for each "+
+		"key value.")
+	connectTimeout = flag.Duration("connect-timeout", time.Minute, "Time to "+
+		"wait for a robot to be found and connected.")
+)
+
+// Connects to a Robomaster S1 or EP, reads every readable key and infers Go
+// types (for the unity/result/value package) from the values returned. A
+// report of which keys answered, errored or timed out is printed to stderr.
+func main() {
+	flag.Parse()
+
+	if *appID == 0 {
+		store, err := identity.Open("")
+		if err != nil {
+			panic(err)
+		}
+
+		for _, r := range store.Robots() {
+			// Robots only seen in pairing mode have no app ID.
+			if r.AppID != 0 {
+				*appID = r.AppID
+				break
+			}
+		}
+	}
+
+	l := logger.New(slog.LevelError)
+
+	f := fleet.New(*appID, func(b *finder.Broadcast) (unitybridge.UnityBridge,
+		error) {
+		return unitybridge.Get(wrapper.Get(l), false, l), nil
+	}, l)
+
+	if err := f.Start(); err != nil {
+		panic(err)
+	}
+	defer f.Stop()
+
+	r, err := f.WaitForRobot(*connectTimeout)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Connected to robot", r)
+
+	var reports []*Report
+	for _, k := range key.ByAccessType(key.AccessTypeRead) {
+		report := probe(r.Bridge(), k, *timeout)
+		reports = append(reports, report)
+	}
+
+	src, err := generate(reports, *known)
+	if err != nil {
+		panic(err)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+	} else if err = os.WriteFile(*output, src, 0644); err != nil {
+		panic(err)
+	}
+
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			panic(err)
+		}
+
+		if err = os.WriteFile(*reportPath, data, 0644); err != nil {
+			panic(err)
+		}
+	}
+
+	printSummary(os.Stderr, reports)
+}
+
+// generate returns the Go types inferred from the given reports.
+func generate(reports []*Report, known bool) ([]byte, error) {
+	g := newGenerator()
+
+	for _, report := range reports {
+		if report.ValueType != "" && !known {
+			continue
+		}
+
+		var n *node
+		for _, sample := range report.Samples() {
+			sampleNode, err := infer(sample)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", report.Key, err)
+			}
+
+			n = merge(n, sampleNode)
+		}
+
+		if n != nil {
+			g.add(report.Key, n)
+		}
+	}
+
+	return g.source()
+}
+
+func printSummary(w io.Writer, reports []*Report) {
+	counts := make(map[Status]int)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, report := range reports {
+		counts[report.Status]++
+
+		detail := report.Error
+		if report.Status != StatusErrored {
+			detail = fmt.Sprintf("%d sample(s)", len(report.Samples()))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", report.Key, report.Status, detail)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d keys: %d answered, %d errored, %d timed out\n",
+		len(reports), counts[StatusAnswered], counts[StatusErrored],
+		counts[StatusTimedOut])
+}
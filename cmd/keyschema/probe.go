@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+)
+
+// Status is the outcome of probing a key.
+type Status string
+
+const (
+	StatusAnswered Status = "answered"
+	StatusErrored  Status = "errored"
+	StatusTimedOut Status = "timed out"
+)
+
+// Report is the result of probing a single key.
+type Report struct {
+	Key         string          `json:"key"`
+	SubType     uint32          `json:"subType"`
+	ValueType   string          `json:"valueType,omitempty"`
+	Status      Status          `json:"status"`
+	ErrorCode   int32           `json:"errorCode,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	CachedError string          `json:"cachedError,omitempty"`
+	Cached      json.RawMessage `json:"cached,omitempty"`
+	Value       json.RawMessage `json:"value,omitempty"`
+}
+
+// Samples returns all raw values captured for the key.
+func (r *Report) Samples() []json.RawMessage {
+	var samples []json.RawMessage
+	for _, sample := range []json.RawMessage{r.Cached, r.Value} {
+		if len(sample) > 0 && string(sample) != "null" {
+			samples = append(samples, sample)
+		}
+	}
+
+	return samples
+}
+
+// probe reads the cached and current values of the given key.
+func probe(ub unitybridge.UnityBridge, k *key.Key,
+	timeout time.Duration) *Report {
+	report := &Report{
+		Key:       k.String(),
+		SubType:   k.SubType(),
+		ValueType: k.ValueTypeName(),
+	}
+
+	// Errors reading the cached value do not change the status (the key
+	// might just not have been read yet), but are kept for reference.
+	r, err := ub.GetCachedKeyValue(k)
+	switch {
+	case err != nil:
+		report.CachedError = err.Error()
+	case !r.Succeeded():
+		report.CachedError = r.ErrorDesc()
+	default:
+		report.Cached = rawValue(r)
+	}
+
+	ch := make(chan *result.Result, 1)
+
+	err = ub.GetKeyValue(k, func(r *result.Result) {
+		ch <- r
+	})
+	if err != nil {
+		report.Status = StatusErrored
+		report.Error = err.Error()
+		return report
+	}
+
+	select {
+	case r := <-ch:
+		if !r.Succeeded() {
+			report.Status = StatusErrored
+			report.ErrorCode = r.ErrorCode()
+			report.Error = r.ErrorDesc()
+			return report
+		}
+
+		report.Status = StatusAnswered
+		report.Value = rawValue(r)
+	case <-time.After(timeout):
+		report.Status = StatusTimedOut
+	}
+
+	return report
+}
+
+// rawValue returns the JSON value of the given result. Values of keys with
+// unknown types are already raw. Typed values are marshaled back to JSON.
+func rawValue(r *result.Result) json.RawMessage {
+	if raw, ok := r.Value().(json.RawMessage); ok {
+		return raw
+	}
+
+	data, err := json.Marshal(r.Value())
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
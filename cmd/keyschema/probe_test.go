@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBridge is a UnityBridge that answers key reads with fixed results. Only
+// the methods used by probe are implemented.
+type fakeBridge struct {
+	unitybridge.UnityBridge
+
+	cached    *result.Result
+	cachedErr error
+	value     *result.Result
+}
+
+func (f *fakeBridge) GetCachedKeyValue(k *key.Key) (*result.Result, error) {
+	return f.cached, f.cachedErr
+}
+
+func (f *fakeBridge) GetKeyValue(k *key.Key, c result.Callback) error {
+	if f.value != nil {
+		go c(f.value)
+	}
+
+	return nil
+}
+
+func TestProbe(t *testing.T) {
+	k := key.KeyAirLinkConnection
+
+	ub := &fakeBridge{
+		cachedErr: fmt.Errorf("not cached"),
+		value:     result.New(k, 0, 0, "", &value.Bool{Value: true}),
+	}
+
+	report := probe(ub, k, time.Second)
+	assert.Equal(t, StatusAnswered, report.Status)
+	assert.Equal(t, "not cached", report.CachedError)
+	assert.Empty(t, report.Cached)
+	assert.JSONEq(t, `{"value":true}`, string(report.Value))
+
+	ub = &fakeBridge{
+		cached: result.New(k, 0, -1, "cache error", &value.Bool{}),
+	}
+
+	report = probe(ub, k, 10*time.Millisecond)
+	assert.Equal(t, StatusTimedOut, report.Status)
+	assert.Equal(t, "cache error", report.CachedError)
+}
@@ -0,0 +1,341 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// kind is the inferred kind of a JSON value.
+type kind int
+
+const (
+	kindNull kind = iota // No information (null or empty list).
+	kindBool
+	kindUint
+	kindInt
+	kindFloat
+	kindString
+	kindList
+	kindObject
+	kindAny // Conflicting samples.
+)
+
+// node is the inferred schema of a JSON value.
+type node struct {
+	kind   kind
+	elem   *node    // kindList only.
+	fields []*field // kindObject only, in the order first seen.
+}
+
+// field is a named field in an inferred JSON object.
+type field struct {
+	name string
+	node *node
+}
+
+// infer returns the schema for the given JSON data. Multiple samples of the
+// same value can be combined with merge.
+func infer(data []byte) (*node, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return inferValue(v), nil
+}
+
+func inferValue(v any) *node {
+	switch v := v.(type) {
+	case nil:
+		return &node{kind: kindNull}
+	case bool:
+		return &node{kind: kindBool}
+	case string:
+		return &node{kind: kindString}
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			return &node{kind: kindFloat}
+		}
+		if strings.HasPrefix(v.String(), "-") {
+			return &node{kind: kindInt}
+		}
+		return &node{kind: kindUint}
+	case []any:
+		n := &node{kind: kindList, elem: &node{kind: kindNull}}
+		for _, elem := range v {
+			n.elem = merge(n.elem, inferValue(elem))
+		}
+		return n
+	case map[string]any:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		n := &node{kind: kindObject}
+		for _, name := range names {
+			n.fields = append(n.fields, &field{name, inferValue(v[name])})
+		}
+		return n
+	}
+
+	return &node{kind: kindAny}
+}
+
+// merge returns a schema compatible with both given schemas.
+func merge(a, b *node) *node {
+	switch {
+	case a == nil || a.kind == kindNull:
+		return b
+	case b == nil || b.kind == kindNull:
+		return a
+	case isNumber(a.kind) && isNumber(b.kind):
+		return &node{kind: max(a.kind, b.kind)}
+	case a.kind != b.kind:
+		return &node{kind: kindAny}
+	case a.kind == kindList:
+		return &node{kind: kindList, elem: merge(a.elem, b.elem)}
+	case a.kind == kindObject:
+		n := &node{kind: kindObject}
+		for _, f := range a.fields {
+			n.fields = append(n.fields, &field{f.name, f.node})
+		}
+		for _, f := range b.fields {
+			if existing := n.field(f.name); existing != nil {
+				existing.node = merge(existing.node, f.node)
+			} else {
+				n.fields = append(n.fields, &field{f.name, f.node})
+			}
+		}
+		return n
+	}
+
+	return a
+}
+
+func (n *node) field(name string) *field {
+	for _, f := range n.fields {
+		if f.name == name {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func isNumber(k kind) bool {
+	return k == kindUint || k == kindInt || k == kindFloat
+}
+
+// valueNames are the names already exported by the unity/result/value
+// package. Generated types never use them so they can be added to the package
+// as is.
+var valueNames = []string{
+	"ArmorHit",
+	"ArmorResetStatus",
+	"AttitudeInfo",
+	"Bool",
+	"ChassisPosition",
+	"ChassisSpeed",
+	"Float64",
+	"FunctionEnable",
+	"FunctionEnableInfo",
+	"GimbalAngleRotation",
+	"GimbalAttitude",
+	"GimbalSpeedRotation",
+	"LEDColor",
+	"LEDLightEffect",
+	"List",
+	"MotorInfo",
+	"RelativePosition",
+	"TaskStatus",
+	"Uint64",
+	"Value",
+	"Void",
+	"WheelSpeed",
+}
+
+// generator generates Go type definitions for the unity/result/value package
+// from inferred schemas.
+type generator struct {
+	b     bytes.Buffer
+	names map[string]bool
+}
+
+func newGenerator() *generator {
+	g := &generator{
+		names: make(map[string]bool),
+	}
+
+	for _, name := range valueNames {
+		g.names[name] = true
+	}
+
+	fmt.Fprintf(&g.b, "// Code generated by keyschema from robot responses. "+
+		"Review before use.\n\n")
+	fmt.Fprintf(&g.b, "package value\n")
+
+	return g
+}
+
+// add adds the type definition for the value of the given key. The top level
+// {"value": ...} and {"list": [...]} shapes are mapped to the existing Value
+// and List generic types. It returns the name of the added type.
+func (g *generator) add(keyName string, n *node) string {
+	name := g.uniqueName(strings.TrimPrefix(keyName, "Key"))
+
+	doc := fmt.Sprintf("%s is the result value for %s.", name, keyName)
+
+	if n.kind == kindObject && len(n.fields) == 1 {
+		f := n.fields[0]
+
+		switch {
+		case f.name == "value":
+			g.typeDef(doc, name, fmt.Sprintf("Value[%s]",
+				g.goType(name+"Value", f.node)))
+			return name
+		case f.name == "list" && f.node.kind == kindList:
+			g.typeDef(doc, name, fmt.Sprintf("List[%s]",
+				g.goType(name+"Info", f.node.elem)))
+			return name
+		}
+	}
+
+	if n.kind == kindObject {
+		g.structDef(doc, name, n)
+	} else {
+		g.typeDef(doc, name, g.goType(name+"Value", n))
+	}
+
+	return name
+}
+
+// source returns the formatted source for all added types.
+func (g *generator) source() ([]byte, error) {
+	return format.Source(g.b.Bytes())
+}
+
+func (g *generator) typeDef(doc, name, typ string) {
+	fmt.Fprintf(&g.b, "\n// %s\ntype %s %s\n", doc, name, typ)
+}
+
+func (g *generator) structDef(doc, name string, n *node) {
+	// Generate the struct body first as it might add nested types.
+	var body strings.Builder
+
+	fieldNames := make(map[string]bool)
+	for _, f := range n.fields {
+		fieldName := exportedName(f.name)
+		for i := 2; fieldNames[fieldName]; i++ {
+			fieldName = fmt.Sprintf("%s%d", exportedName(f.name), i)
+		}
+		fieldNames[fieldName] = true
+
+		fmt.Fprintf(&body, "\t%s %s `json:%q`\n", fieldName,
+			g.goType(name+fieldName, f.node), f.name)
+	}
+
+	fmt.Fprintf(&g.b, "\n// %s\ntype %s struct {\n%s}\n", doc, name,
+		body.String())
+}
+
+// goType returns the Go type for the given schema. Objects are added as new
+// struct types with the given name.
+func (g *generator) goType(name string, n *node) string {
+	switch n.kind {
+	case kindBool:
+		return "bool"
+	case kindUint:
+		return "uint64"
+	case kindInt:
+		return "int64"
+	case kindFloat:
+		return "float64"
+	case kindString:
+		return "string"
+	case kindList:
+		return "[]" + g.goType(name, n.elem)
+	case kindObject:
+		name = g.uniqueName(name)
+		g.structDef(fmt.Sprintf("%s is part of an inferred result value.",
+			name), name, n)
+		return name
+	}
+
+	return "any"
+}
+
+func (g *generator) uniqueName(name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[unique] = true
+
+	return unique
+}
+
+// initialisms are words that are fully capitalized in exported names.
+var initialisms = map[string]bool{
+	"id":  true,
+	"ip":  true,
+	"led": true,
+	"sn":  true,
+	"tof": true,
+	"url": true,
+}
+
+// exportedName returns an exported Go identifier for the given JSON field
+// name (for example, "taskId" becomes "TaskID").
+func exportedName(jsonName string) string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	for _, r := range jsonName {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	var sb strings.Builder
+	for _, w := range words {
+		if initialisms[strings.ToLower(w)] {
+			sb.WriteString(strings.ToUpper(w))
+			continue
+		}
+
+		runes := []rune(w)
+		sb.WriteRune(unicode.ToUpper(runes[0]))
+		sb.WriteString(string(runes[1:]))
+	}
+
+	name := sb.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "Field" + name
+	}
+
+	return name
+}
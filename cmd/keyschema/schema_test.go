@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"pitch":        "Pitch",
+		"taskId":       "TaskID",
+		"yaw_opposite": "YawOpposite",
+		"tofValue":     "TOFValue",
+		"1st":          "Field1st",
+	}
+	for jsonName, want := range tests {
+		assert.Equal(t, want, exportedName(jsonName), jsonName)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a, err := infer([]byte(`{"x":1,"list":[]}`))
+	assert.NoError(t, err)
+
+	b, err := infer([]byte(`{"x":-1.5,"y":"s","list":[true]}`))
+	assert.NoError(t, err)
+
+	n := merge(a, b)
+	assert.Equal(t, kindObject, n.kind)
+	assert.Equal(t, kindFloat, n.field("x").node.kind)
+	assert.Equal(t, kindString, n.field("y").node.kind)
+	assert.Equal(t, kindBool, n.field("list").node.elem.kind)
+
+	c, err := infer([]byte(`{"x":"conflict"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, kindAny, merge(n, c).field("x").node.kind)
+}
+
+func TestGenerate(t *testing.T) {
+	reports := []*Report{
+		{
+			Key:    "KeyProductType",
+			Status: StatusAnswered,
+			Value:  json.RawMessage(`{"value":3}`),
+		},
+		{
+			Key:    "KeyArmorInfo",
+			Status: StatusAnswered,
+			Value:  json.RawMessage(`{"list":[{"id":1,"hit":false}]}`),
+		},
+		{
+			Key:    "KeyChassisInfo",
+			Status: StatusTimedOut,
+			Cached: json.RawMessage(`{"positionX":0.5,"taskId":1}`),
+		},
+		{
+			Key:       "KeyAlreadyKnown",
+			ValueType: "Bool",
+			Status:    StatusAnswered,
+			Value:     json.RawMessage(`{"value":true}`),
+		},
+		{
+			Key:    "KeyErrored",
+			Status: StatusErrored,
+		},
+	}
+
+	src, err := generate(reports, false)
+	assert.NoError(t, err)
+
+	want := `// Code generated by keyschema from robot responses. Review before use.
+
+package value
+
+// ProductType is the result value for KeyProductType.
+type ProductType Value[uint64]
+
+// ArmorInfoInfo is part of an inferred result value.
+type ArmorInfoInfo struct {
+	Hit bool   ` + "`json:\"hit\"`" + `
+	ID  uint64 ` + "`json:\"id\"`" + `
+}
+
+// ArmorInfo is the result value for KeyArmorInfo.
+type ArmorInfo List[ArmorInfoInfo]
+
+// ChassisInfo is the result value for KeyChassisInfo.
+type ChassisInfo struct {
+	PositionX float64 ` + "`json:\"positionX\"`" + `
+	TaskID    uint64  ` + "`json:\"taskId\"`" + `
+}
+`
+	assert.Equal(t, want, string(src))
+}
+
+func TestGenerate_ExistingNames(t *testing.T) {
+	reports := []*Report{
+		{
+			Key:       "KeyGimbalAttitude",
+			ValueType: "GimbalAttitude",
+			Status:    StatusAnswered,
+			Value:     json.RawMessage(`{"pitch":1.5}`),
+		},
+	}
+
+	src, err := generate(reports, true)
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), "type GimbalAttitude2 struct")
+}
+
+func TestValueNames(t *testing.T) {
+	files, err := filepath.Glob("../../unity/result/value/*.go")
+	assert.NoError(t, err)
+
+	var exported []string
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+		assert.NoError(t, err)
+
+		for _, decl := range f.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					exported = append(exported, decl.Name.Name)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						exported = append(exported, spec.Name.Name)
+					case *ast.ValueSpec:
+						for _, name := range spec.Names {
+							exported = append(exported, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	var names []string
+	for _, name := range exported {
+		if ast.IsExported(name) {
+			names = append(names, name)
+		}
+	}
+
+	// Update valueNames when this fails.
+	assert.ElementsMatch(t, valueNames, names)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/qrcode"
+)
+
+var (
+	ssid     = flag.String("ssid", "", "SSID of the network the robot should connect to.")
+	password = flag.String("password", "", "Password of the network the robot "+
+		"should connect to.")
+	countryCode = flag.String("country", "CN", "Two letter country code.")
+	bssid       = flag.String("bssid", "", "Optional BSSID of the network the "+
+		"robot should connect to.")
+	appID = flag.Uint64("appid", 0, "App ID to use. If 0, a random one will be "+
+		"generated.")
+	output = flag.String("output", "", "File to save the QR code to. The "+
+		"format (PNG or SVG) is selected by the file extension. If empty, the "+
+		"QR code is printed to the terminal.")
+	size  = flag.Int("size", 256, "Size, in pixels, of the saved QR code.")
+	light = flag.Bool("light", false, "Render for terminals with a light "+
+		"background.")
+)
+
+// Prints or saves the QR code used to pair a Robomaster S1 or EP with an app ID
+// and to tell it about the network to connect to.
+func main() {
+	flag.Parse()
+
+	if strings.TrimSpace(*ssid) == "" || strings.TrimSpace(*password) == "" {
+		panic("SSID and password must be provided.")
+	}
+
+	if *appID == 0 {
+		var err error
+		*appID, err = support.GenerateAppID()
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	qrCode, err := qrcode.New(*appID, *countryCode, *ssid, *password, *bssid)
+	if err != nil {
+		panic(err)
+	}
+
+	if *output == "" {
+		polarity := qrcode.PolarityDarkBackground
+		if *light {
+			polarity = qrcode.PolarityLightBackground
+		}
+
+		text, err := qrCode.CompactText(polarity)
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Print(text)
+		fmt.Println("App ID:", *appID)
+
+		return
+	}
+
+	if err = saveQRCode(qrCode, *output, *size); err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Saved QR code for app ID", *appID, "to", *output)
+}
+
+func saveQRCode(qrCode *qrcode.QRCode, path string, size int) error {
+	var write func(f *os.File) error
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		write = func(f *os.File) error {
+			return qrCode.WritePNG(f, size)
+		}
+	case ".svg":
+		write = func(f *os.File) error {
+			return qrCode.WriteSVG(f, size)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %s", path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err = write(f); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
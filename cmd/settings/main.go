@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/support/fleet"
+	"github.com/brunoga/unitybridge/support/identity"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/settings"
+	"github.com/brunoga/unitybridge/wrapper"
+)
+
+var (
+	appID = flag.Uint64("appid", 0, "App ID of the robot to connect to. If 0, "+
+		"the app ID of the last robot seen is used (if any).")
+	save = flag.String("save", "", "File to save a snapshot of the robot "+
+		"settings to.")
+	restore = flag.String("restore", "", "Snapshot file to restore the robot "+
+		"settings from.")
+	diff = flag.Bool("diff", false, "Only show what would change when "+
+		"restoring.")
+	connectTimeout = flag.Duration("connect-timeout", time.Minute, "Time to "+
+		"wait for a robot to be found and connected.")
+)
+
+// Saves the settings of a Robomaster S1 or EP to a snapshot file or restores
+// them from one.
+func main() {
+	flag.Parse()
+
+	if (*save == "") == (*restore == "") {
+		panic("Exactly one of -save or -restore must be provided.")
+	}
+
+	if *appID == 0 {
+		store, err := identity.Open("")
+		if err != nil {
+			panic(err)
+		}
+
+		for _, r := range store.Robots() {
+			// Robots only seen in pairing mode have no app ID.
+			if r.AppID != 0 {
+				*appID = r.AppID
+				break
+			}
+		}
+	}
+
+	// Load the snapshot before connecting so errors are reported early.
+	var s *settings.Snapshot
+	if *restore != "" {
+		var err error
+		s, err = settings.Load(*restore)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	l := logger.New(slog.LevelError)
+
+	f := fleet.New(*appID, func(b *finder.Broadcast) (unitybridge.UnityBridge,
+		error) {
+		return unitybridge.Get(wrapper.Get(l), false, l), nil
+	}, l)
+
+	if err := f.Start(); err != nil {
+		panic(err)
+	}
+	defer f.Stop()
+
+	r, err := f.WaitForRobot(*connectTimeout)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Connected to robot", r)
+
+	if *save != "" {
+		s, err := settings.Capture(r.Bridge())
+		if err != nil {
+			fmt.Println("Some settings could not be read:", err)
+		}
+
+		if err = s.Save(*save); err != nil {
+			panic(err)
+		}
+
+		fmt.Println("Saved", len(s.Settings), "settings to", *save)
+
+		return
+	}
+
+	var changes []*settings.Change
+	if *diff {
+		changes, err = settings.Diff(r.Bridge(), s)
+	} else {
+		changes, err = settings.Restore(r.Bridge(), s)
+	}
+
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+
+	if err != nil {
+		panic(err)
+	}
+
+	if *diff {
+		fmt.Println(len(changes), "setting(s) would change")
+	} else {
+		fmt.Println(len(changes), "setting(s) changed")
+	}
+}
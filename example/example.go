@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/binary"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/brunoga/unitybridge"
 	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/support/identity"
 	"github.com/brunoga/unitybridge/support/logger"
 	"github.com/brunoga/unitybridge/support/qrcode"
 	"github.com/brunoga/unitybridge/unity/event"
@@ -25,8 +27,8 @@ var (
 	ssid     = flag.String("ssid", "", "SSID of the network to connect to.")
 	password = flag.String("password", "", "Password of the network to connect "+
 		"to.")
-	appID = flag.Uint64("appid", 0, "App ID to use. If 0, a random one will be "+
-		"generated.")
+	appID = flag.Uint64("appid", 0, "App ID to use. If 0, the app ID of the "+
+		"last robot seen is used (if any).")
 )
 
 // Simple example of connecting to Robomaster S1 or EP. This *REQUIRES* a
@@ -37,7 +39,26 @@ var (
 func main() {
 	flag.Parse()
 
-	if strings.TrimSpace(*ssid) == "" || strings.TrimSpace(*password) == "" {
+	// Previously paired robots do not need any flags.
+	store, err := identity.Open("")
+	if err != nil {
+		panic(err)
+	}
+
+	knownRobot := false
+	if *appID == 0 {
+		for _, r := range store.Robots() {
+			// Robots only seen in pairing mode have no app ID.
+			if r.AppID != 0 {
+				*appID = r.AppID
+				knownRobot = true
+				break
+			}
+		}
+	}
+
+	if !knownRobot && (strings.TrimSpace(*ssid) == "" ||
+		strings.TrimSpace(*password) == "") {
 		panic("SSID and password must be provided.")
 	}
 
@@ -48,15 +69,15 @@ func main() {
 	ub := unitybridge.Get(wrapper.Get(l), true, l)
 
 	// Start unity bridge.
-	err := ub.Start()
+	err = ub.Start()
 	if err != nil {
 		panic(err)
 	}
 	defer ub.Stop()
 
-	if *appID != 0 {
+	if *appID != 0 && !knownRobot {
 		// And generate a QRCode to pair a Robomaster.
-		qrCode, err := qrcode.New(*appID, "CN", "Discworld", "zwergschnauzer", "")
+		qrCode, err := qrcode.New(*appID, "CN", *ssid, *password, "")
 		if err != nil {
 			panic(err)
 		}
@@ -138,11 +159,41 @@ func main() {
 
 	time.Sleep(5 * time.Second)
 
+	// Remember this robot so we can reconnect to it later.
+	err = store.PutBroadcast(broadcast, readSerialNumber(ub))
+	if err != nil {
+		panic(err)
+	}
+
 	closeRobotConnection(ub)
 
 	wg.Wait()
 }
 
+// readSerialNumber returns the serial number of the connected robot or an
+// empty string if it could not be read.
+func readSerialNumber(ub unitybridge.UnityBridge) string {
+	r, err := ub.GetKeyValueSync(key.KeyRobomasterSystemSerialNumber, false)
+	if err != nil || r == nil || !r.Succeeded() {
+		return ""
+	}
+
+	// The serial number key value type is unknown, so it is read as raw JSON.
+	raw, ok := r.Value().(json.RawMessage)
+	if !ok {
+		return ""
+	}
+
+	var serialNumber struct {
+		Value string `json:"value"`
+	}
+	if err = json.Unmarshal(raw, &serialNumber); err != nil {
+		return ""
+	}
+
+	return serialNumber.Value
+}
+
 // resetRobotConnection should be called whenever the IP for the robot
 // changes. It is safe to call it whenever a connection needs to be
 // stablished anyway.
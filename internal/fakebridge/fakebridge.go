@@ -0,0 +1,350 @@
+// Package fakebridge provides an in-memory UnityBridge to be used in tests of
+// packages built on top of it.
+package fakebridge
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/event"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Action is an action performed through the Bridge.
+type Action struct {
+	Key   *key.Key
+	Value any
+}
+
+// SentEvent is an event sent through the Bridge. Data is nil, a string or an
+// uint64 depending on the method used to send it.
+type SentEvent struct {
+	Event *event.Event
+	Data  any
+}
+
+// ActionHandler is called for every action performed through the Bridge. If
+// it returns an error, the action fails with it.
+type ActionHandler func(k *key.Key, v any) error
+
+// Bridge is an in-memory UnityBridge. Like the real one, it indexes listeners
+// by key sub-type, supports any number of listeners per key and checks key
+// access and value types. Values are kept in memory, actions, directly sent
+// values and events are recorded and results sent with Send are delivered to
+// the key listeners synchronously. Methods not used by the support packages are
+// not implemented and panic if called. It is thread safe.
+type Bridge struct {
+	unitybridge.UnityBridge
+
+	tg *token.Generator
+
+	m         sync.Mutex
+	started   bool
+	listeners map[uint32]map[token.Token]result.Callback
+	values    map[uint32]any
+	actions   []Action
+	sent      []uint64
+	events    []SentEvent
+	onAction  ActionHandler
+}
+
+// New returns a new Bridge instance.
+func New() *Bridge {
+	return &Bridge{
+		tg:        token.NewGenerator(),
+		listeners: make(map[uint32]map[token.Token]result.Callback),
+		values:    make(map[uint32]any),
+	}
+}
+
+// Start implements unitybridge.UnityBridge.
+func (b *Bridge) Start() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.started {
+		return fmt.Errorf("bridge already started")
+	}
+
+	b.started = true
+
+	return nil
+}
+
+// Stop implements unitybridge.UnityBridge.
+func (b *Bridge) Stop() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if !b.started {
+		return fmt.Errorf("bridge not started")
+	}
+
+	b.started = false
+
+	return nil
+}
+
+// AddKeyListener implements unitybridge.UnityBridge.
+func (b *Bridge) AddKeyListener(k *key.Key, c result.Callback,
+	immediate bool) (token.Token, error) {
+	if k.AccessType()&key.AccessTypeRead == 0 {
+		return 0, fmt.Errorf("key %s is not readable", k)
+	}
+
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	t := b.tg.Next()
+
+	b.m.Lock()
+
+	if _, ok := b.listeners[k.SubType()]; !ok {
+		b.listeners[k.SubType()] = make(map[token.Token]result.Callback)
+	}
+
+	b.listeners[k.SubType()][t] = c
+
+	v, ok := b.values[k.SubType()]
+
+	b.m.Unlock()
+
+	if immediate && ok {
+		// As in the real bridge, the callback is not called in the caller
+		// goroutine as it might be holding locks the callback needs.
+		go c(result.New(k, 0, 0, "", v))
+	}
+
+	return t, nil
+}
+
+// RemoveKeyListener implements unitybridge.UnityBridge.
+func (b *Bridge) RemoveKeyListener(k *key.Key, t token.Token) error {
+	if t == 0 {
+		return fmt.Errorf("token cannot be 0")
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if _, ok := b.listeners[k.SubType()][t]; !ok {
+		return fmt.Errorf("no listener registered with token %d for key %s",
+			t, k)
+	}
+
+	delete(b.listeners[k.SubType()], t)
+
+	if len(b.listeners[k.SubType()]) == 0 {
+		delete(b.listeners, k.SubType())
+	}
+
+	return nil
+}
+
+// GetKeyValueSync implements unitybridge.UnityBridge. Values are always
+// returned from memory.
+func (b *Bridge) GetKeyValueSync(k *key.Key,
+	useCache bool) (*result.Result, error) {
+	if k.AccessType()&key.AccessTypeRead == 0 {
+		return nil, fmt.Errorf("key %s is not readable", k)
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	v, ok := b.values[k.SubType()]
+	if !ok {
+		return nil, fmt.Errorf("no value for key %s", k)
+	}
+
+	return result.New(k, 0, 0, "", v), nil
+}
+
+// SetKeyValueSync implements unitybridge.UnityBridge.
+func (b *Bridge) SetKeyValueSync(k *key.Key, v any) error {
+	if k.AccessType()&key.AccessTypeWrite == 0 {
+		return fmt.Errorf("key %s is not writable", k)
+	}
+
+	if reflect.TypeOf(v) != k.ValueType() {
+		return fmt.Errorf("value type %s does not match expected key %s type "+
+			"%s", reflect.TypeOf(v), k, k.ValueType())
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.values[k.SubType()] = v
+
+	return nil
+}
+
+// PerformActionForKeySync implements unitybridge.UnityBridge. The action is
+// recorded and passed to the handler set with HandleActions, if any.
+func (b *Bridge) PerformActionForKeySync(k *key.Key, v any) error {
+	if k.AccessType()&key.AccessTypeAction == 0 {
+		return fmt.Errorf("key %s is not an action", k)
+	}
+
+	if k.ValueType() == reflect.TypeOf(&value.Void{}) {
+		if v != nil {
+			return fmt.Errorf("key %s is void type but value is not nil", k)
+		}
+	} else if reflect.TypeOf(v) != k.ValueType() {
+		return fmt.Errorf("value type %s does not match expected key %s type "+
+			"%s", reflect.TypeOf(v), k, k.ValueType())
+	}
+
+	b.m.Lock()
+	b.actions = append(b.actions, Action{k, v})
+	onAction := b.onAction
+	b.m.Unlock()
+
+	if onAction != nil {
+		return onAction(k, v)
+	}
+
+	return nil
+}
+
+// DirectSendKeyValue implements unitybridge.UnityBridge. Sent values are
+// recorded and can be obtained with Sent.
+func (b *Bridge) DirectSendKeyValue(k *key.Key, v uint64) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.sent = append(b.sent, v)
+
+	return nil
+}
+
+// SendEvent implements unitybridge.UnityBridge. Sent events are recorded and
+// can be obtained with Events.
+func (b *Bridge) SendEvent(ev *event.Event) error {
+	return b.recordEvent(ev, nil)
+}
+
+// SendEventWithString implements unitybridge.UnityBridge. Sent events are
+// recorded and can be obtained with Events.
+func (b *Bridge) SendEventWithString(ev *event.Event, data string) error {
+	return b.recordEvent(ev, data)
+}
+
+// SendEventWithUint64 implements unitybridge.UnityBridge. Sent events are
+// recorded and can be obtained with Events.
+func (b *Bridge) SendEventWithUint64(ev *event.Event, data uint64) error {
+	return b.recordEvent(ev, data)
+}
+
+// Started returns true if the Bridge is started.
+func (b *Bridge) Started() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.started
+}
+
+// SetValue sets the value for the given key without notifying listeners.
+func (b *Bridge) SetValue(k *key.Key, v any) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.values[k.SubType()] = v
+}
+
+// Value returns the value for the given key or nil if there is none.
+func (b *Bridge) Value(k *key.Key) any {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.values[k.SubType()]
+}
+
+// Send sets the value for the given key and sends it to all the key
+// listeners, returning after all of them were called.
+func (b *Bridge) Send(k *key.Key, v any) {
+	b.m.Lock()
+
+	b.values[k.SubType()] = v
+
+	callbacks := make([]result.Callback, 0, len(b.listeners[k.SubType()]))
+	for _, c := range b.listeners[k.SubType()] {
+		callbacks = append(callbacks, c)
+	}
+
+	b.m.Unlock()
+
+	for _, c := range callbacks {
+		c(result.New(k, 0, 0, "", v))
+	}
+}
+
+// Listeners returns the number of listeners registered for the given key.
+func (b *Bridge) Listeners(k *key.Key) int {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return len(b.listeners[k.SubType()])
+}
+
+// HandleActions sets the handler to be called for every action performed.
+func (b *Bridge) HandleActions(h ActionHandler) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.onAction = h
+}
+
+// Actions returns all the actions performed so far.
+func (b *Bridge) Actions() []Action {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return append([]Action(nil), b.actions...)
+}
+
+// ActionKeys returns the keys of all the actions performed so far.
+func (b *Bridge) ActionKeys() []*key.Key {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	keys := make([]*key.Key, len(b.actions))
+	for i, a := range b.actions {
+		keys[i] = a.Key
+	}
+
+	return keys
+}
+
+// Sent returns all the values sent with DirectSendKeyValue so far.
+func (b *Bridge) Sent() []uint64 {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return append([]uint64(nil), b.sent...)
+}
+
+// Events returns all the events sent so far.
+func (b *Bridge) Events() []SentEvent {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return append([]SentEvent(nil), b.events...)
+}
+
+func (b *Bridge) recordEvent(ev *event.Event, data any) error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	// Events might be changed by the caller after being sent.
+	evCopy := *ev
+	b.events = append(b.events, SentEvent{&evCopy, data})
+
+	return nil
+}
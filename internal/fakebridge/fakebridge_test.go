@@ -0,0 +1,101 @@
+package fakebridge
+
+import (
+	"testing"
+
+	"github.com/brunoga/unitybridge/unity/event"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartStop(t *testing.T) {
+	b := New()
+
+	assert.Error(t, b.Stop())
+	assert.NoError(t, b.Start())
+	assert.Error(t, b.Start())
+	assert.True(t, b.Started())
+	assert.NoError(t, b.Stop())
+	assert.False(t, b.Started())
+}
+
+func TestListeners(t *testing.T) {
+	b := New()
+
+	var got1, got2 []any
+
+	t1, err := b.AddKeyListener(key.KeyAirLinkConnection,
+		func(r *result.Result) {
+			got1 = append(got1, r.Value())
+		}, false)
+	assert.NoError(t, err)
+
+	t2, err := b.AddKeyListener(key.KeyAirLinkConnection,
+		func(r *result.Result) {
+			got2 = append(got2, r.Value())
+		}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, b.Listeners(key.KeyAirLinkConnection))
+
+	connected := &value.Bool{Value: true}
+	b.Send(key.KeyAirLinkConnection, connected)
+	assert.Equal(t, []any{connected}, got1)
+	assert.Equal(t, []any{connected}, got2)
+
+	assert.NoError(t, b.RemoveKeyListener(key.KeyAirLinkConnection, t1))
+	assert.Error(t, b.RemoveKeyListener(key.KeyAirLinkConnection, t1))
+	assert.Error(t, b.RemoveKeyListener(key.KeyGimbalWorkMode, t2))
+
+	b.Send(key.KeyAirLinkConnection, connected)
+	assert.Len(t, got1, 1)
+	assert.Len(t, got2, 2)
+
+	assert.NoError(t, b.RemoveKeyListener(key.KeyAirLinkConnection, t2))
+	assert.Equal(t, 0, b.Listeners(key.KeyAirLinkConnection))
+}
+
+func TestValuesAndActions(t *testing.T) {
+	b := New()
+
+	_, err := b.GetKeyValueSync(key.KeyAirLinkConnection, true)
+	assert.Error(t, err)
+
+	b.SetValue(key.KeyAirLinkConnection, &value.Bool{Value: true})
+
+	r, err := b.GetKeyValueSync(key.KeyAirLinkConnection, true)
+	assert.NoError(t, err)
+	assert.Equal(t, &value.Bool{Value: true}, r.Value())
+
+	k := key.KeyMainControllerChassisCarControlMode
+
+	assert.Error(t, b.SetKeyValueSync(key.KeyAirLinkConnection,
+		&value.Bool{}))
+	assert.Error(t, b.SetKeyValueSync(k, &value.Bool{}))
+	assert.NoError(t, b.SetKeyValueSync(k, &value.Uint64{Value: 1}))
+	assert.Equal(t, &value.Uint64{Value: 1}, b.Value(k))
+
+	assert.Error(t, b.PerformActionForKeySync(k, nil))
+	assert.Error(t, b.PerformActionForKeySync(
+		key.KeyGimbalOpenAttitudeUpdates, &value.Uint64{}))
+	assert.NoError(t, b.PerformActionForKeySync(
+		key.KeyGimbalOpenAttitudeUpdates, nil))
+	assert.Equal(t, []*key.Key{key.KeyGimbalOpenAttitudeUpdates},
+		b.ActionKeys())
+}
+
+func TestEvents(t *testing.T) {
+	b := New()
+
+	ev := event.NewFromTypeAndSubType(event.TypeConnection, 2)
+	assert.NoError(t, b.SendEventWithString(ev, "127.0.0.1"))
+
+	ev.ResetSubType(3)
+	assert.NoError(t, b.SendEventWithUint64(ev, 10607))
+
+	assert.Equal(t, []SentEvent{
+		{event.NewFromTypeAndSubType(event.TypeConnection, 2), "127.0.0.1"},
+		{event.NewFromTypeAndSubType(event.TypeConnection, 3), uint64(10607)},
+	}, b.Events())
+}
@@ -0,0 +1,22 @@
+// Package nettest provides network helpers for tests.
+package nettest
+
+import (
+	"net"
+	"testing"
+)
+
+// FreeUDPPort returns a local UDP port that was free when checked. This allows
+// tests that use network ports to run in parallel without interfering with
+// each other or with any robots in the network.
+func FreeUDPPort(t testing.TB) int {
+	t.Helper()
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4zero})
+	if err != nil {
+		t.Fatalf("error finding free UDP port: %s", err)
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
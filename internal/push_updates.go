@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// pushUpdate describes the actions that must be performed for a key to start
+// and stop pushing updates to its listeners.
+type pushUpdate struct {
+	open       *key.Key
+	openValue  any
+	close      *key.Key
+	closeValue any
+}
+
+// pushUpdates maps the sub-type of keys that only push updates after a
+// companion action is performed to the actions that control them.
+var pushUpdates = map[uint32]pushUpdate{
+	key.KeyGimbalAttitude.SubType(): {
+		open:  key.KeyGimbalOpenAttitudeUpdates,
+		close: key.KeyGimbalCloseAttitudeUpdates,
+	},
+	key.KeyRobomasterChassisSpeed.SubType(): {
+		open:  key.KeyRobomasterOpenChassisSpeedUpdates,
+		close: key.KeyRobomasterCloseChassisSpeedUpdates,
+	},
+	key.KeyRobomasterClawInfoSubscribe.SubType(): enableSubscribe(
+		key.KeyRobomasterEnableClawInfoSubscribe),
+	key.KeyRobomasterArmPositionSubscribe.SubType(): enableSubscribe(
+		key.KeyRobomasterEnableArmInfoSubscribe),
+	key.KeyRobomasterTOFInfoSubscribe.SubType(): enableSubscribe(
+		key.KeyRobomasterEnableTOFInfoSubscribe),
+	key.KeyRobomasterServoInfoSubscribe.SubType(): enableSubscribe(
+		key.KeyRobomasterEnableServoInfoSubscribe),
+	key.KeyRobomasterSensorAdapterInfoSubscribe.SubType(): enableSubscribe(
+		key.KeyRobomasterEnableSensorAdapterInfoSubscribe),
+}
+
+// enableSubscribe returns a pushUpdate for subscriptions that are enabled
+// and disabled by performing the same action with a true or false value.
+func enableSubscribe(k *key.Key) pushUpdate {
+	return pushUpdate{
+		open:       k,
+		openValue:  &value.Bool{Value: true},
+		close:      k,
+		closeValue: &value.Bool{Value: false},
+	}
+}
+
+// retainPushUpdates enables push updates for the given key, if it needs
+// that, when it gets its first listener.
+func (u *UnityBridgeImpl) retainPushUpdates(k *key.Key) {
+	pu, ok := pushUpdates[k.SubType()]
+	if !ok {
+		return
+	}
+
+	u.pm.Lock()
+	defer u.pm.Unlock()
+
+	u.pushUpdateRefs[k.SubType()]++
+	if u.pushUpdateRefs[k.SubType()] == 1 {
+		u.performPushUpdateAction(k, pu.open, pu.openValue)
+	}
+}
+
+// releasePushUpdates disables push updates for the given key, if it needs
+// that, when its last listener is removed.
+func (u *UnityBridgeImpl) releasePushUpdates(k *key.Key) {
+	pu, ok := pushUpdates[k.SubType()]
+	if !ok {
+		return
+	}
+
+	u.pm.Lock()
+	defer u.pm.Unlock()
+
+	if u.pushUpdateRefs[k.SubType()] == 0 {
+		return
+	}
+
+	u.pushUpdateRefs[k.SubType()]--
+	if u.pushUpdateRefs[k.SubType()] == 0 {
+		delete(u.pushUpdateRefs, k.SubType())
+		u.performPushUpdateAction(k, pu.close, pu.closeValue)
+	}
+}
+
+func (u *UnityBridgeImpl) performPushUpdateAction(k, action *key.Key,
+	value any) {
+	err := u.PerformActionForKey(action, value, func(r *result.Result) {
+		if !r.Succeeded() {
+			u.l.Error("Push updates action failed", "key", k, "action",
+				action, "result", r)
+		}
+	})
+	if err != nil {
+		u.l.Error("Error performing push updates action", "key", k,
+			"action", action, "err", err)
+	}
+}
@@ -0,0 +1,98 @@
+package internal
+
+import (
+	"testing"
+
+	"github.com/brunoga/unitybridge/unity/event"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	wrapper_mock "github.com/brunoga/unitybridge/wrapper/mock"
+)
+
+func eventCode(t event.Type, k *key.Key) uint64 {
+	return event.NewFromTypeAndSubType(t, k.SubType()).Code()
+}
+
+func TestPushUpdates_OpenAndClose(t *testing.T) {
+	uw := wrapper_mock.NewUnityBridgeWrapper()
+	u := NewUnityBridgeImpl(uw, false, nil)
+
+	k := key.KeyGimbalAttitude
+	c := func(r *result.Result) {}
+
+	uw.On("SendEvent", eventCode(event.TypeStartListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+	uw.On("SendEvent", eventCode(event.TypePerformAction,
+		key.KeyGimbalOpenAttitudeUpdates), []byte(nil), mock.Anything).
+		Return(nil).Once()
+
+	t1, err := u.AddKeyListener(k, c, false)
+	assert.NoError(t, err)
+
+	// Only the first listener opens updates.
+	t2, err := u.AddKeyListener(k, c, false)
+	assert.NoError(t, err)
+
+	uw.AssertExpectations(t)
+
+	uw.On("SendEvent", eventCode(event.TypeStopListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+	uw.On("SendEvent", eventCode(event.TypePerformAction,
+		key.KeyGimbalCloseAttitudeUpdates), []byte(nil), mock.Anything).
+		Return(nil).Once()
+
+	// Only the last listener closes updates.
+	assert.NoError(t, u.RemoveKeyListener(k, t1))
+	assert.NoError(t, u.RemoveKeyListener(k, t2))
+	assert.Error(t, u.RemoveKeyListener(k, t2))
+
+	uw.AssertExpectations(t)
+	assert.Empty(t, u.pushUpdateRefs)
+}
+
+func TestPushUpdates_EnableSubscribe(t *testing.T) {
+	uw := wrapper_mock.NewUnityBridgeWrapper()
+	u := NewUnityBridgeImpl(uw, false, nil)
+
+	k := key.KeyRobomasterTOFInfoSubscribe
+	action := key.KeyRobomasterEnableTOFInfoSubscribe
+
+	uw.On("SendEvent", eventCode(event.TypeStartListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+	uw.On("SendEventWithString", eventCode(event.TypePerformAction, action),
+		`{"value":true}`, mock.Anything).Once()
+
+	tk, err := u.AddKeyListener(k, func(r *result.Result) {}, false)
+	assert.NoError(t, err)
+
+	uw.On("SendEvent", eventCode(event.TypeStopListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+	uw.On("SendEventWithString", eventCode(event.TypePerformAction, action),
+		`{"value":false}`, mock.Anything).Once()
+
+	assert.NoError(t, u.RemoveKeyListener(k, tk))
+
+	uw.AssertExpectations(t)
+}
+
+func TestPushUpdates_OtherKeys(t *testing.T) {
+	uw := wrapper_mock.NewUnityBridgeWrapper()
+	u := NewUnityBridgeImpl(uw, false, nil)
+
+	k := key.KeyAirLinkConnection
+
+	uw.On("SendEvent", eventCode(event.TypeStartListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+	uw.On("SendEvent", eventCode(event.TypeStopListening, k), []byte(nil),
+		uint64(0)).Return(nil).Once()
+
+	tk, err := u.AddKeyListener(k, func(r *result.Result) {}, false)
+	assert.NoError(t, err)
+	assert.NoError(t, u.RemoveKeyListener(k, tk))
+
+	uw.AssertExpectations(t)
+	assert.Empty(t, u.pushUpdateRefs)
+}
@@ -28,11 +28,16 @@ type UnityBridgeImpl struct {
 	l                *logger.Logger
 	tg               *token.Generator
 
-	m                  sync.RWMutex
-	started            bool
+	m       sync.RWMutex
+	started bool
 	keyListeners       map[*key.Key]map[token.Token]result.Callback
 	eventTypeListeners map[event.Type]map[token.Token]event.TypeCallback
 	callbackListener   map[token.Token]result.Callback
+
+	// Serializes the actions that enable and disable push updates so they
+	// are sent in the same order their reference counts change.
+	pm             sync.Mutex
+	pushUpdateRefs map[uint32]int
 }
 
 func NewUnityBridgeImpl(uw wrapper.UnityBridge,
@@ -50,6 +55,7 @@ func NewUnityBridgeImpl(uw wrapper.UnityBridge,
 		keyListeners:       make(map[*key.Key]map[token.Token]result.Callback),
 		eventTypeListeners: make(map[event.Type]map[token.Token]event.TypeCallback),
 		callbackListener:   make(map[token.Token]result.Callback),
+		pushUpdateRefs:     make(map[uint32]int),
 	}
 }
 
@@ -111,6 +117,8 @@ func (u *UnityBridgeImpl) AddKeyListener(k *key.Key, c result.Callback,
 
 	u.m.Unlock()
 
+	u.retainPushUpdates(k)
+
 	if !immediate {
 		return t, nil
 	}
@@ -136,13 +144,14 @@ func (u *UnityBridgeImpl) RemoveKeyListener(k *key.Key, token token.Token) error
 	}
 
 	u.m.Lock()
-	defer u.m.Unlock()
 
 	if _, ok := u.keyListeners[k]; !ok {
+		u.m.Unlock()
 		return fmt.Errorf("no listeners registered for key %s", k)
 	}
 
 	if _, ok := u.keyListeners[k][token]; !ok {
+		u.m.Unlock()
 		return fmt.Errorf("no listener registered with token %d for key %s",
 			token, k)
 	}
@@ -155,6 +164,10 @@ func (u *UnityBridgeImpl) RemoveKeyListener(k *key.Key, token token.Token) error
 		delete(u.keyListeners, k)
 	}
 
+	u.m.Unlock()
+
+	u.releasePushUpdates(k)
+
 	return nil
 }
 
@@ -240,11 +253,11 @@ func (u *UnityBridgeImpl) SetKeyValue(k *key.Key, value any,
 		return fmt.Errorf("key %s is not writable", k)
 	}
 
-	expectedKeyValue := k.ResultValue()
+	expectedType := k.ValueType()
 
-	if reflect.TypeOf(value) != reflect.TypeOf(expectedKeyValue) {
+	if reflect.TypeOf(value) != expectedType {
 		return fmt.Errorf("value type %s does not match expected key %s type "+
-			"%s", reflect.TypeOf(value), k, reflect.TypeOf(expectedKeyValue))
+			"%s", reflect.TypeOf(value), k, expectedType)
 	}
 
 	data, err := json.Marshal(value)
@@ -298,8 +311,7 @@ func (u *UnityBridgeImpl) PerformActionForKey(k *key.Key, value any,
 		return fmt.Errorf("key %s is not an action", k)
 	}
 
-	expectedKeyValue := k.ResultValue()
-	expectedType := reflect.TypeOf(expectedKeyValue)
+	expectedType := k.ValueType()
 	actualType := reflect.TypeOf(value)
 
 	if expectedType == voidType {
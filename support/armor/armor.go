@@ -0,0 +1,173 @@
+package armor
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Armor reports hits detected by the robot armors (KeyArmorUnderAttack and
+// KeyRobomasterSystemUnderAbilitiesAttack) as typed events and keeps per
+// armor hit counters. It also drives armor ID assignments. It is thread
+// safe.
+type Armor struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+	d  *support.Dispatcher[Hit]
+
+	m          sync.Mutex
+	tokens     map[*key.Key]token.Token
+	counts     map[uint8]uint64
+	assignment *Assignment
+}
+
+// New returns a new Armor instance for the robot connected to the given
+// UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *Armor {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Armor{
+		ub:     ub,
+		l:      l.WithGroup("armor"),
+		d:      support.NewDispatcher[Hit](),
+		counts: make(map[uint8]uint64),
+	}
+}
+
+// Start starts tracking armor hits.
+func (a *Armor) Start() error {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.tokens != nil {
+		return fmt.Errorf("armor already started")
+	}
+
+	a.tokens = make(map[*key.Key]token.Token)
+
+	callbacks := map[*key.Key]result.Callback{
+		key.KeyArmorUnderAttack: func(r *result.Result) {
+			a.onHit(r, false)
+		},
+		key.KeyRobomasterSystemUnderAbilitiesAttack: func(r *result.Result) {
+			a.onHit(r, true)
+		},
+	}
+
+	for k, c := range callbacks {
+		t, err := a.ub.AddKeyListener(k, c, false)
+		if err != nil {
+			return errors.Join(err, a.stopLocked())
+		}
+
+		a.tokens[k] = t
+	}
+
+	return nil
+}
+
+// Stop stops tracking armor hits.
+func (a *Armor) Stop() error {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	if a.tokens == nil {
+		return fmt.Errorf("armor not started")
+	}
+
+	return a.stopLocked()
+}
+
+// AddListener adds a callback to be called for every detected hit. Hits are
+// delivered in the order they were detected. It returns a token that can be
+// used to remove it later.
+func (a *Armor) AddListener(c HitCallback) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	return a.d.AddListener(c)
+}
+
+// RemoveListener removes the callback associated with the given token.
+func (a *Armor) RemoveListener(t token.Token) error {
+	return a.d.RemoveListener(t)
+}
+
+// Hits returns the number of hits detected by the given armor since the
+// counters were last reset.
+func (a *Armor) Hits(armorID uint8) uint64 {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	return a.counts[armorID]
+}
+
+// HitCounts returns the number of hits detected by each armor since the
+// counters were last reset. Armors that were not hit are not included.
+func (a *Armor) HitCounts() map[uint8]uint64 {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	counts := make(map[uint8]uint64, len(a.counts))
+	for id, n := range a.counts {
+		counts[id] = n
+	}
+
+	return counts
+}
+
+// ResetCounters resets all armor hit counters.
+func (a *Armor) ResetCounters() {
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.counts = make(map[uint8]uint64)
+}
+
+func (a *Armor) onHit(r *result.Result, ability bool) {
+	if !r.Succeeded() {
+		a.l.Error("Error reading armor hit", "result", r)
+		return
+	}
+
+	v := r.Value().(*value.ArmorHit)
+
+	hit := Hit{
+		ArmorID: v.ArmorID,
+		Type:    HitType(v.Type),
+		Time:    time.Now(),
+		Ability: ability,
+	}
+
+	a.l.Debug("Armor hit", "hit", hit)
+
+	a.m.Lock()
+	defer a.m.Unlock()
+
+	a.counts[hit.ArmorID]++
+	a.d.Dispatch(hit)
+}
+
+func (a *Armor) stopLocked() error {
+	var err error
+	for k, t := range a.tokens {
+		err = errors.Join(err, a.ub.RemoveKeyListener(k, t))
+	}
+
+	a.tokens = nil
+
+	return err
+}
@@ -0,0 +1,227 @@
+package armor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartStop(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+
+	assert.NoError(t, a.Start())
+	assert.Error(t, a.Start())
+	assert.Equal(t, 1, ub.Listeners(key.KeyArmorUnderAttack))
+	assert.Equal(t, 1,
+		ub.Listeners(key.KeyRobomasterSystemUnderAbilitiesAttack))
+
+	assert.NoError(t, a.Stop())
+	assert.Error(t, a.Stop())
+	assert.Equal(t, 0, ub.Listeners(key.KeyArmorUnderAttack))
+	assert.Equal(t, 0,
+		ub.Listeners(key.KeyRobomasterSystemUnderAbilitiesAttack))
+}
+
+func TestHits(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+	assert.NoError(t, a.Start())
+	defer a.Stop()
+
+	ch := make(chan Hit, 10)
+	tk, err := a.AddListener(func(h Hit) {
+		ch <- h
+	})
+	assert.NoError(t, err)
+
+	ub.Send(key.KeyArmorUnderAttack, &value.ArmorHit{ArmorID: 2, Type: 1})
+
+	select {
+	case h := <-ch:
+		assert.Equal(t, uint8(2), h.ArmorID)
+		assert.Equal(t, HitTypeInfrared, h.Type)
+		assert.False(t, h.Ability)
+		assert.False(t, h.Time.IsZero())
+	case <-time.After(time.Second):
+		t.Fatal("no hit event")
+	}
+
+	ub.Send(key.KeyRobomasterSystemUnderAbilitiesAttack,
+		&value.ArmorHit{ArmorID: 2, Type: 0})
+
+	select {
+	case h := <-ch:
+		assert.Equal(t, HitTypeWater, h.Type)
+		assert.True(t, h.Ability)
+	case <-time.After(time.Second):
+		t.Fatal("no hit event")
+	}
+
+	ub.Send(key.KeyArmorUnderAttack, &value.ArmorHit{ArmorID: 5, Type: 2})
+
+	assert.Equal(t, uint64(2), a.Hits(2))
+	assert.Equal(t, map[uint8]uint64{2: 2, 5: 1}, a.HitCounts())
+
+	a.ResetCounters()
+	assert.Equal(t, uint64(0), a.Hits(2))
+	assert.Empty(t, a.HitCounts())
+
+	assert.NoError(t, a.RemoveListener(tk))
+	assert.Error(t, a.RemoveListener(tk))
+}
+
+func TestHits_Ordered(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+	assert.NoError(t, a.Start())
+	defer a.Stop()
+
+	ch := make(chan Hit, 100)
+	_, err := a.AddListener(func(h Hit) {
+		ch <- h
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		ub.Send(key.KeyArmorUnderAttack, &value.ArmorHit{ArmorID: uint8(i)})
+	}
+
+	for i := 0; i < 100; i++ {
+		select {
+		case h := <-ch:
+			assert.Equal(t, uint8(i), h.ArmorID)
+		case <-time.After(time.Second):
+			t.Fatal("no hit event")
+		}
+	}
+}
+
+func TestAssignIDs(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+
+	ids := make(chan uint8, 10)
+	as, err := a.AssignIDs(func(id uint8) {
+		ids <- id
+	})
+	assert.NoError(t, err)
+
+	_, err = a.AssignIDs(nil)
+	assert.Error(t, err)
+
+	status := func(id uint8, state AssignmentState) {
+		ub.Send(key.KeyArmorResetStatus,
+			&value.ArmorResetStatus{CurrentID: id, State: uint8(state)})
+	}
+
+	status(1, AssignmentStateWaiting)
+	assert.Equal(t, uint8(1), <-ids)
+
+	// Repeated status for the same ID is not reported again.
+	status(1, AssignmentStateWaiting)
+	assert.NoError(t, as.Skip())
+
+	status(2, AssignmentStateWaiting)
+	assert.Equal(t, uint8(2), <-ids)
+	assert.Equal(t, uint8(2), as.CurrentID())
+
+	status(2, AssignmentStateDone)
+
+	assert.NoError(t, as.Wait(context.Background()))
+	assert.Equal(t, 0, ub.Listeners(key.KeyArmorResetStatus))
+	assert.Error(t, as.Skip())
+
+	assert.Equal(t, []*key.Key{key.KeyArmorEnterResetID,
+		key.KeyArmorSkipCurrentID}, ub.ActionKeys())
+
+	// A new assignment can be started after the previous one is done.
+	as, err = a.AssignIDs(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, as.Cancel())
+	assert.NoError(t, as.Cancel())
+}
+
+func TestAssignIDs_OrderedCallbacks(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+
+	ids := make(chan uint8, 10)
+	as, err := a.AssignIDs(func(id uint8) {
+		ids <- id
+	})
+	assert.NoError(t, err)
+	defer as.Cancel()
+
+	for id := uint8(1); id <= 5; id++ {
+		ub.Send(key.KeyArmorResetStatus, &value.ArmorResetStatus{
+			CurrentID: id, State: uint8(AssignmentStateWaiting)})
+	}
+
+	for id := uint8(1); id <= 5; id++ {
+		assert.Equal(t, id, <-ids)
+	}
+}
+
+// earlyStatusBridge is a fake bridge that reports a finished armor ID
+// assignment as soon as a listener is added, before the listener token is
+// returned.
+type earlyStatusBridge struct {
+	*fakebridge.Bridge
+}
+
+func (b earlyStatusBridge) AddKeyListener(k *key.Key, c result.Callback,
+	immediate bool) (token.Token, error) {
+	t, err := b.Bridge.AddKeyListener(k, c, immediate)
+	if err == nil {
+		c(result.New(k, 0, 0, "", &value.ArmorResetStatus{
+			State: uint8(AssignmentStateDone)}))
+	}
+
+	return t, err
+}
+
+func TestAssignIDs_FinishedBeforeToken(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(earlyStatusBridge{ub}, nil)
+
+	as, err := a.AssignIDs(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, as.Wait(context.Background()))
+	assert.Equal(t, 0, ub.Listeners(key.KeyArmorResetStatus))
+
+	// The armor is free for a new assignment.
+	as, err = a.AssignIDs(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, as.Wait(context.Background()))
+}
+
+func TestAssignIDs_WaitTimeout(t *testing.T) {
+	ub := fakebridge.New()
+	a := New(ub, nil)
+
+	as, err := a.AssignIDs(nil)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, as.Wait(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, as.Err(), ErrCanceled)
+	actions := ub.ActionKeys()
+	assert.Equal(t, key.KeyArmorCancelResetID, actions[len(actions)-1])
+}
+
+func TestHitType_String(t *testing.T) {
+	assert.Equal(t, "Water", HitTypeWater.String())
+	assert.Equal(t, "Impact", HitTypeImpact.String())
+	assert.Equal(t, "Unknown(9)", HitType(9).String())
+}
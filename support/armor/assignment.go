@@ -0,0 +1,247 @@
+package armor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// ErrCanceled is returned by Assignment.Wait() when the assignment was
+// canceled.
+var ErrCanceled = errors.New("armor ID assignment canceled")
+
+// AssignmentState is the state of an armor ID assignment as reported through
+// KeyArmorResetStatus.
+type AssignmentState uint8
+
+const (
+	AssignmentStateIdle AssignmentState = iota
+	AssignmentStateWaiting
+	AssignmentStateDone
+)
+
+// String returns the string representation of the AssignmentState.
+func (s AssignmentState) String() string {
+	switch s {
+	case AssignmentStateIdle:
+		return "Idle"
+	case AssignmentStateWaiting:
+		return "Waiting"
+	case AssignmentStateDone:
+		return "Done"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(s))
+}
+
+// AssignmentCallback is called whenever an armor ID assignment starts waiting
+// for the armor that should get the given ID to be hit.
+type AssignmentCallback func(currentID uint8)
+
+// Assignment is a handle to a guided armor ID assignment started by an Armor.
+// The robot assigns IDs in order to each armor that is hit. The ID currently
+// being assigned can be skipped and the whole assignment can be canceled. It
+// is thread safe.
+type Assignment struct {
+	a *Armor
+	d *support.Dispatcher[uint8]
+
+	m         sync.Mutex
+	t         token.Token
+	currentID uint8
+	done      chan struct{}
+	finished  bool
+	err       error
+}
+
+// AssignIDs starts a guided armor ID assignment (KeyArmorEnterResetID). The
+// given callback, if not nil, is called with each ID that is waiting for its
+// armor to be hit. Calls are made in the order IDs are reported. Only one
+// assignment can run at a time.
+func (a *Armor) AssignIDs(c AssignmentCallback) (*Assignment, error) {
+	as := &Assignment{
+		a:    a,
+		d:    support.NewDispatcher[uint8](),
+		done: make(chan struct{}),
+	}
+
+	if c != nil {
+		as.d.AddListener(c)
+	}
+
+	a.m.Lock()
+
+	if a.assignment != nil {
+		a.m.Unlock()
+		return nil, fmt.Errorf("armor ID assignment already running")
+	}
+
+	a.assignment = as
+
+	a.m.Unlock()
+
+	t, err := a.ub.AddKeyListener(key.KeyArmorResetStatus, as.onStatus, false)
+	if err != nil {
+		as.finish(err)
+		return nil, err
+	}
+
+	as.m.Lock()
+	finished := as.finished
+	if !finished {
+		as.t = t
+	}
+	as.m.Unlock()
+
+	if finished {
+		// A status update finished the assignment before the token was set,
+		// so finish could not remove the listener.
+		a.ub.RemoveKeyListener(key.KeyArmorResetStatus, t)
+		return as, nil
+	}
+
+	err = a.ub.PerformActionForKeySync(key.KeyArmorEnterResetID, nil)
+	if err != nil {
+		as.finish(err)
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// CurrentID returns the ID currently waiting for its armor to be hit. It
+// returns 0 before the robot reports the first one.
+func (as *Assignment) CurrentID() uint8 {
+	as.m.Lock()
+	defer as.m.Unlock()
+
+	return as.currentID
+}
+
+// Done returns a channel that is closed when the assignment completes or is
+// canceled.
+func (as *Assignment) Done() <-chan struct{} {
+	return as.done
+}
+
+// Err returns nil if the assignment is still running or completed
+// successfully. Otherwise it returns the reason it did not complete.
+func (as *Assignment) Err() error {
+	as.m.Lock()
+	defer as.m.Unlock()
+
+	return as.err
+}
+
+// Wait waits for the assignment to complete. It returns nil if it completed
+// successfully, ErrCanceled if it was canceled or another error if it
+// failed. If the given context is done before that, the assignment is
+// canceled and the context error is returned.
+func (as *Assignment) Wait(ctx context.Context) error {
+	select {
+	case <-as.done:
+		return as.Err()
+	case <-ctx.Done():
+		as.Cancel()
+		return ctx.Err()
+	}
+}
+
+// Skip skips the ID currently being assigned (KeyArmorSkipCurrentID).
+func (as *Assignment) Skip() error {
+	as.m.Lock()
+	finished := as.finished
+	as.m.Unlock()
+
+	if finished {
+		return fmt.Errorf("armor ID assignment not running")
+	}
+
+	return as.a.ub.PerformActionForKeySync(key.KeyArmorSkipCurrentID, nil)
+}
+
+// Cancel cancels the assignment if it is still running
+// (KeyArmorCancelResetID).
+func (as *Assignment) Cancel() error {
+	as.m.Lock()
+	finished := as.finished
+	as.m.Unlock()
+
+	if finished {
+		return nil
+	}
+
+	err := as.a.ub.PerformActionForKeySync(key.KeyArmorCancelResetID, nil)
+	if err != nil {
+		return err
+	}
+
+	as.finish(ErrCanceled)
+
+	return nil
+}
+
+func (as *Assignment) onStatus(r *result.Result) {
+	if !r.Succeeded() {
+		as.a.l.Error("Error reading armor reset status", "result", r)
+		return
+	}
+
+	v := r.Value().(*value.ArmorResetStatus)
+	state := AssignmentState(v.State)
+
+	as.a.l.Debug("Armor ID assignment status", "currentID", v.CurrentID,
+		"state", state)
+
+	if state == AssignmentStateDone {
+		as.finish(nil)
+		return
+	}
+
+	as.m.Lock()
+	changed := !as.finished && v.CurrentID != as.currentID
+	as.currentID = v.CurrentID
+	as.m.Unlock()
+
+	if changed && state == AssignmentStateWaiting {
+		as.d.Dispatch(v.CurrentID)
+	}
+}
+
+func (as *Assignment) finish(err error) {
+	as.m.Lock()
+
+	if as.finished {
+		as.m.Unlock()
+		return
+	}
+
+	as.finished = true
+	as.err = err
+	close(as.done)
+
+	t := as.t
+
+	as.m.Unlock()
+
+	if t != 0 {
+		if err := as.a.ub.RemoveKeyListener(key.KeyArmorResetStatus,
+			t); err != nil {
+			as.a.l.Warn("Error removing armor reset status listener",
+				"error", err)
+		}
+	}
+
+	as.a.m.Lock()
+	if as.a.assignment == as {
+		as.a.assignment = nil
+	}
+	as.a.m.Unlock()
+}
@@ -0,0 +1,50 @@
+package armor
+
+import (
+	"fmt"
+	"time"
+)
+
+// HitType is what hit an armor.
+type HitType uint8
+
+const (
+	HitTypeWater HitType = iota
+	HitTypeInfrared
+	HitTypeImpact
+)
+
+// String returns the string representation of the HitType.
+func (t HitType) String() string {
+	switch t {
+	case HitTypeWater:
+		return "Water"
+	case HitTypeInfrared:
+		return "Infrared"
+	case HitTypeImpact:
+		return "Impact"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(t))
+}
+
+// Hit is a hit detected by one of the robot armors.
+type Hit struct {
+	ArmorID uint8
+	Type    HitType
+	Time    time.Time
+
+	// Ability is true if the hit was reported as an abilities attack
+	// (KeyRobomasterSystemUnderAbilitiesAttack) instead of a direct armor
+	// hit (KeyArmorUnderAttack).
+	Ability bool
+}
+
+// String returns a human readable representation of the Hit.
+func (h Hit) String() string {
+	return fmt.Sprintf("armor %d hit by %s at %s", h.ArmorID, h.Type,
+		h.Time.Format(time.RFC3339Nano))
+}
+
+// HitCallback is called for every hit detected.
+type HitCallback func(Hit)
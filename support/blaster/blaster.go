@@ -0,0 +1,278 @@
+package blaster
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// MaxBurst is the maximum number of shots in a single burst.
+const MaxBurst = 8
+
+var (
+	// ErrDisarmed is returned when trying to fire while the blaster is not
+	// armed.
+	ErrDisarmed = errors.New("blaster not armed")
+
+	// ErrCoolingDown is returned when trying to fire while the barrel is
+	// cooling down.
+	ErrCoolingDown = errors.New("blaster cooling down")
+)
+
+// Blaster controls the robot water gun and infrared gun. Firing is blocked
+// unless the blaster was explicitly armed and while the barrel is cooling
+// down (KeyRobomasterSystemGunCoolDown). It is thread safe.
+type Blaster struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+
+	// Held for reading while firing and for writing while disarming, so
+	// shots can not be fired after the blaster is disarmed.
+	fm sync.RWMutex
+
+	m     sync.Mutex
+	rl    *support.ResultListener
+	armed bool
+}
+
+// New returns a new Blaster instance that controls the blaster of the robot
+// connected to the given UnityBridge. The blaster starts disarmed.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *Blaster {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Blaster{
+		ub: ub,
+		l:  l.WithGroup("blaster"),
+	}
+}
+
+// Start starts tracking the barrel cooldown state. It must be called before
+// firing.
+func (b *Blaster) Start() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.rl != nil {
+		return fmt.Errorf("blaster already started")
+	}
+
+	rl := support.NewResultListener(b.ub, b.l,
+		key.KeyRobomasterSystemGunCoolDown, nil)
+	if err := rl.Start(); err != nil {
+		return err
+	}
+
+	b.rl = rl
+
+	return nil
+}
+
+// Stop disarms the blaster (see Disarm) and stops tracking the barrel
+// cooldown state.
+func (b *Blaster) Stop() error {
+	b.fm.Lock()
+	defer b.fm.Unlock()
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.rl == nil {
+		return fmt.Errorf("blaster not started")
+	}
+
+	err := b.rl.Stop()
+	b.rl = nil
+	b.armed = false
+
+	return err
+}
+
+// Arm allows the blaster to fire.
+func (b *Blaster) Arm() {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.l.Info("Blaster armed")
+
+	b.armed = true
+}
+
+// Disarm blocks the blaster from firing. It waits for shots being fired to
+// complete, so no shots are fired after it returns.
+func (b *Blaster) Disarm() {
+	b.fm.Lock()
+	defer b.fm.Unlock()
+
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	b.l.Info("Blaster disarmed")
+
+	b.armed = false
+}
+
+// Armed returns true if the blaster is armed.
+func (b *Blaster) Armed() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.armed
+}
+
+// CoolingDown returns true if the barrel is currently cooling down.
+func (b *Blaster) CoolingDown() bool {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	return b.coolingDownLocked()
+}
+
+// WaitForCooldown waits up to the given timeout for the barrel to finish
+// cooling down.
+func (b *Blaster) WaitForCooldown(timeout time.Duration) error {
+	b.m.Lock()
+
+	if b.rl == nil {
+		b.m.Unlock()
+		return fmt.Errorf("blaster not started")
+	}
+
+	rl := b.rl
+	coolingDown := b.coolingDownLocked()
+
+	b.m.Unlock()
+
+	deadline := time.Now().Add(timeout)
+
+	for coolingDown {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timeout waiting for blaster cooldown")
+		}
+
+		r := rl.WaitForNewResult(remaining)
+		if r == nil {
+			continue
+		}
+
+		coolingDown = r.Succeeded() && r.Value().(*value.Bool).Value
+	}
+
+	return nil
+}
+
+// FireOnce fires a single water gun shot.
+func (b *Blaster) FireOnce() error {
+	return b.fire(key.KeyRobomasterWaterGunWaterGunFire, nil)
+}
+
+// FireBurst fires the given number (1 to MaxBurst) of water gun shots.
+func (b *Blaster) FireBurst(n int) error {
+	if n < 1 || n > MaxBurst {
+		return fmt.Errorf("invalid burst size %d (must be between 1 and %d)",
+			n, MaxBurst)
+	}
+
+	return b.fire(key.KeyRobomasterWaterGunWaterGunFireWithTimes,
+		&value.Uint64{Value: uint64(n)})
+}
+
+// FireInfrared fires an infrared gun shot.
+func (b *Blaster) FireInfrared() error {
+	return b.fire(key.KeyRobomasterInfraredGunInfraredGunFire, nil)
+}
+
+// CoolDown asks the robot to cool down the barrel.
+func (b *Blaster) CoolDown() error {
+	return b.ub.PerformActionForKeySync(key.KeyRobomasterSystemBarrelCoolDown,
+		nil)
+}
+
+// ResetOverheat clears the barrel overheat state.
+func (b *Blaster) ResetOverheat() error {
+	return b.ub.PerformActionForKeySync(
+		key.KeyRobomasterSystemResetBarrelOverheat, nil)
+}
+
+// ShootSpeed returns the current water gun shoot speed.
+func (b *Blaster) ShootSpeed() (uint64, error) {
+	return b.readUint64(key.KeyRobomasterWaterGunShootSpeed)
+}
+
+// ShootFrequency returns the current water gun shoot frequency.
+func (b *Blaster) ShootFrequency() (uint64, error) {
+	return b.readUint64(key.KeyRobomasterWaterGunShootFrequency)
+}
+
+// InfraredShootFrequency returns the current infrared gun shoot frequency.
+func (b *Blaster) InfraredShootFrequency() (uint64, error) {
+	return b.readUint64(key.KeyRobomasterInfraredGunShootFrequency)
+}
+
+// fire performs the given fire action if the blaster can fire. The blaster
+// can not be disarmed until the action completes.
+func (b *Blaster) fire(k *key.Key, v any) error {
+	b.fm.RLock()
+	defer b.fm.RUnlock()
+
+	if err := b.checkCanFire(); err != nil {
+		return err
+	}
+
+	return b.ub.PerformActionForKeySync(k, v)
+}
+
+func (b *Blaster) checkCanFire() error {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	if b.rl == nil {
+		return fmt.Errorf("blaster not started")
+	}
+
+	if !b.armed {
+		return ErrDisarmed
+	}
+
+	if b.coolingDownLocked() {
+		return ErrCoolingDown
+	}
+
+	return nil
+}
+
+func (b *Blaster) coolingDownLocked() bool {
+	if b.rl == nil {
+		return false
+	}
+
+	r := b.rl.Result()
+	if r == nil || !r.Succeeded() {
+		return false
+	}
+
+	return r.Value().(*value.Bool).Value
+}
+
+func (b *Blaster) readUint64(k *key.Key) (uint64, error) {
+	r, err := b.ub.GetKeyValueSync(k, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.Succeeded() {
+		return 0, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	return r.Value().(*value.Uint64).Value, nil
+}
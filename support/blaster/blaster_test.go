@@ -0,0 +1,155 @@
+package blaster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func sendCoolDown(ub *fakebridge.Bridge, coolingDown bool) {
+	ub.Send(key.KeyRobomasterSystemGunCoolDown,
+		&value.Bool{Value: coolingDown})
+}
+
+func TestStartStop(t *testing.T) {
+	ub := fakebridge.New()
+	b := New(ub, nil)
+
+	assert.Error(t, b.FireOnce())
+
+	assert.NoError(t, b.Start())
+	assert.Error(t, b.Start())
+
+	b.Arm()
+	assert.True(t, b.Armed())
+
+	// Stopping disarms.
+	assert.NoError(t, b.Stop())
+	assert.Error(t, b.Stop())
+	assert.False(t, b.Armed())
+}
+
+func TestFire_Interlock(t *testing.T) {
+	ub := fakebridge.New()
+	b := New(ub, nil)
+	assert.NoError(t, b.Start())
+	defer b.Stop()
+
+	assert.ErrorIs(t, b.FireOnce(), ErrDisarmed)
+	assert.ErrorIs(t, b.FireBurst(3), ErrDisarmed)
+	assert.ErrorIs(t, b.FireInfrared(), ErrDisarmed)
+	assert.Empty(t, ub.Actions())
+
+	b.Arm()
+
+	assert.NoError(t, b.FireOnce())
+	assert.NoError(t, b.FireBurst(3))
+	assert.NoError(t, b.FireInfrared())
+
+	assert.Equal(t, []*key.Key{
+		key.KeyRobomasterWaterGunWaterGunFire,
+		key.KeyRobomasterWaterGunWaterGunFireWithTimes,
+		key.KeyRobomasterInfraredGunInfraredGunFire,
+	}, ub.ActionKeys())
+	assert.Equal(t, &value.Uint64{Value: 3}, ub.Actions()[1].Value)
+
+	b.Disarm()
+
+	assert.ErrorIs(t, b.FireOnce(), ErrDisarmed)
+}
+
+func TestDisarm_WaitsForShots(t *testing.T) {
+	ub := fakebridge.New()
+	b := New(ub, nil)
+	assert.NoError(t, b.Start())
+	defer b.Stop()
+
+	firing := make(chan struct{})
+	release := make(chan struct{})
+	ub.HandleActions(func(k *key.Key, v any) error {
+		close(firing)
+		<-release
+		return nil
+	})
+
+	b.Arm()
+
+	fired := make(chan error)
+	go func() {
+		fired <- b.FireOnce()
+	}()
+
+	<-firing
+
+	disarmed := make(chan struct{})
+	go func() {
+		b.Disarm()
+		close(disarmed)
+	}()
+
+	select {
+	case <-disarmed:
+		t.Fatal("disarmed while firing")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+
+	assert.NoError(t, <-fired)
+	<-disarmed
+
+	assert.ErrorIs(t, b.FireOnce(), ErrDisarmed)
+	assert.Len(t, ub.Actions(), 1)
+}
+
+func TestFireBurst_InvalidSize(t *testing.T) {
+	ub := fakebridge.New()
+	b := New(ub, nil)
+	assert.NoError(t, b.Start())
+	defer b.Stop()
+
+	b.Arm()
+
+	assert.Error(t, b.FireBurst(0))
+	assert.Error(t, b.FireBurst(MaxBurst+1))
+	assert.Empty(t, ub.Actions())
+}
+
+func TestCooldown(t *testing.T) {
+	ub := fakebridge.New()
+	b := New(ub, nil)
+	assert.NoError(t, b.Start())
+	defer b.Stop()
+
+	b.Arm()
+
+	sendCoolDown(ub, true)
+	assert.True(t, b.CoolingDown())
+	assert.ErrorIs(t, b.FireOnce(), ErrCoolingDown)
+
+	assert.Error(t, b.WaitForCooldown(50*time.Millisecond))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		sendCoolDown(ub, false)
+	}()
+
+	assert.NoError(t, b.WaitForCooldown(time.Second))
+	assert.False(t, b.CoolingDown())
+	assert.NoError(t, b.FireOnce())
+}
+
+func TestShootSpeed(t *testing.T) {
+	ub := fakebridge.New()
+	ub.SetValue(key.KeyRobomasterWaterGunShootSpeed, &value.Uint64{Value: 5})
+
+	b := New(ub, nil)
+
+	speed, err := b.ShootSpeed()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), speed)
+}
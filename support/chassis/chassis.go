@@ -0,0 +1,219 @@
+package chassis
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Chassis controls the movement of a robot chassis through position moves
+// (KeyMainControllerChassisPosition) and wheel speeds. Each position move is a
+// robot task with its own ID and its completion is tracked through
+// KeyRobomasterSystemTaskStatus. It is thread safe.
+type Chassis struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+
+	m          sync.Mutex
+	rl         *support.ResultListener
+	nextTaskID uint8
+	motions    map[uint8]*Motion
+}
+
+// New returns a new Chassis instance that controls the robot connected to the
+// given UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *Chassis {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Chassis{
+		ub:      ub,
+		l:       l.WithGroup("chassis"),
+		motions: make(map[uint8]*Motion),
+	}
+}
+
+// Start starts tracking task status updates. It must be called before any
+// motions are started.
+func (c *Chassis) Start() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.rl != nil {
+		return fmt.Errorf("chassis already started")
+	}
+
+	rl := support.NewResultListener(c.ub, c.l,
+		key.KeyRobomasterSystemTaskStatus, c.onTaskStatus)
+
+	if err := rl.Start(); err != nil {
+		return err
+	}
+
+	c.rl = rl
+
+	return nil
+}
+
+// Stop cancels all running motions and stops tracking task status updates.
+func (c *Chassis) Stop() error {
+	c.m.Lock()
+
+	if c.rl == nil {
+		c.m.Unlock()
+		return fmt.Errorf("chassis not started")
+	}
+
+	rl := c.rl
+	c.rl = nil
+
+	motions := make([]*Motion, 0, len(c.motions))
+	for _, m := range c.motions {
+		motions = append(motions, m)
+	}
+
+	c.m.Unlock()
+
+	for _, m := range motions {
+		if err := m.Cancel(); err != nil {
+			c.l.Warn("Error canceling motion", "taskID", m.TaskID(),
+				"error", err)
+		}
+	}
+
+	return rl.Stop()
+}
+
+// MoveTo moves the chassis to the given position (x and y, in meters, and
+// yaw, in degrees) relative to its current position. It returns a handle
+// that can be used to wait for the motion to complete or to cancel it.
+func (c *Chassis) MoveTo(x, y, yaw float32) (*Motion, error) {
+	c.m.Lock()
+
+	if c.rl == nil {
+		c.m.Unlock()
+		return nil, fmt.Errorf("chassis not started")
+	}
+
+	taskID, err := c.allocateTaskIDLocked()
+	if err != nil {
+		c.m.Unlock()
+		return nil, err
+	}
+
+	m := newMotion(c, taskID)
+	c.motions[taskID] = m
+
+	c.m.Unlock()
+
+	err = c.ub.PerformActionForKeySync(key.KeyMainControllerChassisPosition,
+		&value.ChassisPosition{
+			TaskID: taskID,
+			X:      x,
+			Y:      y,
+			Z:      yaw,
+		})
+	if err != nil {
+		c.removeMotion(taskID)
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// Translate moves the chassis by the given distances (in meters) without
+// rotating it. X is forward and Y is to the right.
+func (c *Chassis) Translate(x, y float32) (*Motion, error) {
+	return c.MoveTo(x, y, 0)
+}
+
+// Rotate rotates the chassis in place by the given angle (in degrees).
+func (c *Chassis) Rotate(yaw float32) (*Motion, error) {
+	return c.MoveTo(0, 0, yaw)
+}
+
+// SetWheelSpeeds sets the speed of each chassis wheel. Use
+// Geometry.Inverse() to obtain the wheel speeds for a body velocity.
+func (c *Chassis) SetWheelSpeeds(w WheelSpeeds) error {
+	return c.ub.SetKeyValueSync(key.KeyMainControllerWheelSpeed, w.Value())
+}
+
+// WheelSpeeds returns the current speed of each chassis wheel as reported
+// by their ESCs. Use Geometry.Forward() to obtain the body velocity for them.
+func (c *Chassis) WheelSpeeds() (WheelSpeeds, error) {
+	var motors [4]*value.MotorInfo
+	for i, k := range ESCKeys {
+		r, err := c.ub.GetKeyValueSync(k, true)
+		if err != nil {
+			return WheelSpeeds{}, err
+		}
+
+		if !r.Succeeded() {
+			return WheelSpeeds{}, fmt.Errorf("error reading key %s: %s", k,
+				r.ErrorDesc())
+		}
+
+		motors[i] = r.Value().(*value.MotorInfo)
+	}
+
+	return WheelSpeedsFromMotors(motors), nil
+}
+
+// allocateTaskIDLocked returns a task ID that is not being used by any
+// running motion. Task ID 0 is never used.
+func (c *Chassis) allocateTaskIDLocked() (uint8, error) {
+	for i := 0; i < 255; i++ {
+		c.nextTaskID++
+		if c.nextTaskID == 0 {
+			c.nextTaskID = 1
+		}
+
+		if _, ok := c.motions[c.nextTaskID]; !ok {
+			return c.nextTaskID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("too many running motions")
+}
+
+func (c *Chassis) cancel(taskID uint8) error {
+	return c.ub.PerformActionForKeySync(key.KeyMainControllerChassisPosition,
+		&value.ChassisPosition{
+			TaskID:   taskID,
+			IsCancel: 1,
+		})
+}
+
+func (c *Chassis) removeMotion(taskID uint8) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	delete(c.motions, taskID)
+}
+
+func (c *Chassis) onTaskStatus(r *result.Result) {
+	status := r.Value().(*value.TaskStatus)
+
+	c.m.Lock()
+	m, ok := c.motions[status.TaskID]
+	c.m.Unlock()
+
+	if !ok {
+		return
+	}
+
+	state := TaskState(status.Status)
+
+	c.l.Debug("Task status", "taskID", status.TaskID, "state", state,
+		"percent", status.Percent)
+
+	m.update(state, status.Percent)
+}
@@ -0,0 +1,178 @@
+package chassis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func sendStatus(ub *fakebridge.Bridge, taskID uint8, state TaskState) {
+	ub.Send(key.KeyRobomasterSystemTaskStatus,
+		&value.TaskStatus{TaskID: taskID, Percent: 100, Status: uint8(state)})
+}
+
+func lastAction(ub *fakebridge.Bridge) *value.ChassisPosition {
+	actions := ub.Actions()
+
+	return actions[len(actions)-1].Value.(*value.ChassisPosition)
+}
+
+func lastSent(ub *fakebridge.Bridge) (uint64, int) {
+	sent := ub.Sent()
+	if len(sent) == 0 {
+		return 0, 0
+	}
+
+	return sent[len(sent)-1], len(sent)
+}
+
+func setupChassis(t *testing.T) (*fakebridge.Bridge, *Chassis) {
+	ub := fakebridge.New()
+
+	c := New(ub, nil)
+	assert.NoError(t, c.Start())
+
+	return ub, c
+}
+
+func waitContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	t.Cleanup(cancel)
+
+	return ctx
+}
+
+func TestMoveTo_Succeeded(t *testing.T) {
+	ub, c := setupChassis(t)
+	defer c.Stop()
+
+	m, err := c.MoveTo(1, 0.5, 90)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(1), m.TaskID())
+	assert.Equal(t, &value.ChassisPosition{TaskID: 1, X: 1, Y: 0.5, Z: 90},
+		lastAction(ub))
+
+	sendStatus(ub, m.TaskID(), TaskStateSucceeded)
+
+	assert.NoError(t, m.Wait(waitContext(t)))
+	assert.Equal(t, uint8(100), m.Progress())
+}
+
+func TestMoveTo_Failed(t *testing.T) {
+	ub, c := setupChassis(t)
+	defer c.Stop()
+
+	m, err := c.Rotate(45)
+	assert.NoError(t, err)
+
+	sendStatus(ub, m.TaskID(), TaskStateFailed)
+
+	err = m.Wait(waitContext(t))
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCanceled)
+}
+
+func TestMotion_Cancel(t *testing.T) {
+	ub, c := setupChassis(t)
+	defer c.Stop()
+
+	m, err := c.Translate(1, 0)
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Cancel())
+	assert.Equal(t, &value.ChassisPosition{TaskID: m.TaskID(), IsCancel: 1},
+		lastAction(ub))
+
+	assert.ErrorIs(t, m.Wait(waitContext(t)), ErrCanceled)
+}
+
+func TestMotion_CancelFailed(t *testing.T) {
+	ub, c := setupChassis(t)
+	defer c.Stop()
+
+	m, err := c.Translate(1, 0)
+	assert.NoError(t, err)
+
+	cancelErr := errors.New("cancel failed")
+	ub.HandleActions(func(k *key.Key, v any) error {
+		return cancelErr
+	})
+
+	err = m.Cancel()
+	assert.ErrorIs(t, err, cancelErr)
+
+	// The motion is finished with the cancel error and not tracked anymore.
+	select {
+	case <-m.Done():
+	default:
+		t.Fatal("motion not finished")
+	}
+
+	assert.Equal(t, err, m.Err())
+	assert.NotErrorIs(t, m.Err(), ErrCanceled)
+	assert.NoError(t, m.Cancel())
+
+	c.m.Lock()
+	assert.NotContains(t, c.motions, m.TaskID())
+	c.m.Unlock()
+}
+
+func TestMoveTo_UniqueTaskIDs(t *testing.T) {
+	_, c := setupChassis(t)
+	defer c.Stop()
+
+	m1, err := c.Rotate(10)
+	assert.NoError(t, err)
+
+	m2, err := c.Rotate(10)
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, m1.TaskID(), m2.TaskID())
+}
+
+func TestQueue(t *testing.T) {
+	ub, c := setupChassis(t)
+	defer c.Stop()
+
+	ub.HandleActions(func(k *key.Key, v any) error {
+		p := v.(*value.ChassisPosition)
+		if p.IsCancel == 0 {
+			sendStatus(ub, p.TaskID, TaskStateSucceeded)
+		}
+
+		return nil
+	})
+
+	q := NewQueue(c)
+	q.Add(Waypoint{X: 1}, Waypoint{Y: 1}, Waypoint{Yaw: 90})
+
+	assert.NoError(t, q.Wait(waitContext(t)))
+	assert.Equal(t, 0, q.Len())
+	assert.Len(t, ub.Actions(), 3)
+	assert.Equal(t, float32(90), lastAction(ub).Z)
+}
+
+func TestQueue_Clear(t *testing.T) {
+	_, c := setupChassis(t)
+	defer c.Stop()
+
+	q := NewQueue(c)
+	q.Add(Waypoint{X: 1}, Waypoint{Y: 1})
+
+	assert.Eventually(t, func() bool {
+		q.m.Lock()
+		defer q.m.Unlock()
+
+		return q.current != nil
+	}, time.Second, time.Millisecond)
+
+	assert.NoError(t, q.Clear())
+	assert.ErrorIs(t, q.Wait(waitContext(t)), ErrCanceled)
+	assert.Equal(t, 0, q.Len())
+}
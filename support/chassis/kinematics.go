@@ -0,0 +1,101 @@
+package chassis
+
+import (
+	"math"
+
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Geometry is the geometry of a chassis with four mecanum wheels in the
+// usual X configuration (rollers forming an X when seen from above).
+type Geometry struct {
+	WheelRadius float64 // Meters.
+	WheelBase   float64 // Distance between front and rear axles, in meters.
+	TrackWidth  float64 // Distance between left and right wheels, in meters.
+}
+
+// DefaultGeometry returns the geometry of the Robomaster S1 and EP chassis.
+func DefaultGeometry() Geometry {
+	return Geometry{
+		WheelRadius: 0.05,
+		WheelBase:   0.2,
+		TrackWidth:  0.2,
+	}
+}
+
+// WheelSpeeds holds the speed, in RPM, of each chassis wheel. Positive
+// speeds roll the robot forward.
+type WheelSpeeds struct {
+	FrontRight float64
+	FrontLeft  float64
+	RearLeft   float64
+	RearRight  float64
+}
+
+// ESCKeys are the motor information keys for each wheel, in the same order
+// as the fields in WheelSpeeds.
+var ESCKeys = [4]*key.Key{
+	key.KeyESCMotorInfomation1,
+	key.KeyESCMotorInfomation2,
+	key.KeyESCMotorInfomation3,
+	key.KeyESCMotorInfomation4,
+}
+
+// WheelSpeedsFromMotors returns the wheel speeds reported by the ESCs of each
+// wheel (in ESCKeys order).
+func WheelSpeedsFromMotors(motors [4]*value.MotorInfo) WheelSpeeds {
+	return WheelSpeeds{
+		FrontRight: float64(motors[0].Speed),
+		FrontLeft:  float64(motors[1].Speed),
+		RearLeft:   float64(motors[2].Speed),
+		RearRight:  float64(motors[3].Speed),
+	}
+}
+
+// Value returns the KeyMainControllerWheelSpeed value for these wheel
+// speeds. Speeds are rounded and clamped to the valid range.
+func (w WheelSpeeds) Value() *value.WheelSpeed {
+	return &value.WheelSpeed{
+		FrontRight: rpmValue(w.FrontRight),
+		FrontLeft:  rpmValue(w.FrontLeft),
+		RearLeft:   rpmValue(w.RearLeft),
+		RearRight:  rpmValue(w.RearRight),
+	}
+}
+
+// Inverse returns the wheel speeds needed for the chassis to move at the
+// given body velocity (inverse kinematics).
+func (g Geometry) Inverse(v Velocity) WheelSpeeds {
+	k := (g.WheelBase + g.TrackWidth) / 2
+	wz := v.Z * math.Pi / 180
+
+	toRPM := 60 / (2 * math.Pi * g.WheelRadius)
+
+	return WheelSpeeds{
+		FrontRight: (v.X - v.Y - k*wz) * toRPM,
+		FrontLeft:  (v.X + v.Y + k*wz) * toRPM,
+		RearLeft:   (v.X - v.Y + k*wz) * toRPM,
+		RearRight:  (v.X + v.Y - k*wz) * toRPM,
+	}
+}
+
+// Forward returns the chassis body velocity that results from the given
+// wheel speeds (forward kinematics).
+func (g Geometry) Forward(w WheelSpeeds) Velocity {
+	k := (g.WheelBase + g.TrackWidth) / 2
+
+	toLinear := 2 * math.Pi * g.WheelRadius / 60 / 4
+
+	return Velocity{
+		X: (w.FrontRight + w.FrontLeft + w.RearLeft + w.RearRight) * toLinear,
+		Y: (-w.FrontRight + w.FrontLeft - w.RearLeft + w.RearRight) * toLinear,
+		Z: (-w.FrontRight + w.FrontLeft + w.RearLeft - w.RearRight) *
+			toLinear / k * 180 / math.Pi,
+	}
+}
+
+func rpmValue(rpm float64) int16 {
+	return int16(math.Max(math.MinInt16, math.Min(math.MaxInt16,
+		math.Round(rpm))))
+}
@@ -0,0 +1,67 @@
+package chassis
+
+import (
+	"testing"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKinematics_RoundTrip(t *testing.T) {
+	g := DefaultGeometry()
+
+	for _, v := range []Velocity{
+		{X: 1},
+		{Y: -0.5},
+		{Z: 90},
+		{X: 0.3, Y: 0.2, Z: -45},
+	} {
+		got := g.Forward(g.Inverse(v))
+		assert.InDelta(t, v.X, got.X, 1e-9)
+		assert.InDelta(t, v.Y, got.Y, 1e-9)
+		assert.InDelta(t, v.Z, got.Z, 1e-9)
+	}
+}
+
+func TestKinematics_Inverse(t *testing.T) {
+	g := DefaultGeometry()
+
+	// Moving forward at 1 m/s spins all wheels at the same speed.
+	w := g.Inverse(Velocity{X: 1})
+	assert.InDelta(t, 190.99, w.FrontRight, 0.01)
+	assert.Equal(t, w.FrontRight, w.FrontLeft)
+	assert.Equal(t, w.FrontRight, w.RearLeft)
+	assert.Equal(t, w.FrontRight, w.RearRight)
+
+	// Rotating clockwise moves left wheels forward and right wheels back.
+	w = g.Inverse(Velocity{Z: 90})
+	assert.Greater(t, w.FrontLeft, 0.0)
+	assert.Greater(t, w.RearLeft, 0.0)
+	assert.Less(t, w.FrontRight, 0.0)
+	assert.Less(t, w.RearRight, 0.0)
+
+	// Moving right moves front left and rear right wheels forward.
+	w = g.Inverse(Velocity{Y: 1})
+	assert.Greater(t, w.FrontLeft, 0.0)
+	assert.Greater(t, w.RearRight, 0.0)
+	assert.Less(t, w.FrontRight, 0.0)
+	assert.Less(t, w.RearLeft, 0.0)
+}
+
+func TestWheelSpeeds_Value(t *testing.T) {
+	w := WheelSpeeds{FrontRight: 10.4, FrontLeft: -10.6, RearLeft: 1e6}
+
+	assert.Equal(t, &value.WheelSpeed{FrontRight: 10, FrontLeft: -11,
+		RearLeft: 32767}, w.Value())
+}
+
+func TestChassis_SetWheelSpeeds(t *testing.T) {
+	ub := fakebridge.New()
+	c := New(ub, nil)
+
+	w := DefaultGeometry().Inverse(Velocity{X: 0.5})
+	assert.NoError(t, c.SetWheelSpeeds(w))
+	assert.Equal(t, w.Value(), ub.Value(key.KeyMainControllerWheelSpeed))
+}
@@ -0,0 +1,160 @@
+package chassis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCanceled is returned by Motion.Wait() when the motion was canceled.
+var ErrCanceled = errors.New("motion canceled")
+
+// TaskState is the state of a robot task as reported through
+// KeyRobomasterSystemTaskStatus.
+type TaskState uint8
+
+const (
+	TaskStateRunning TaskState = iota
+	TaskStateSucceeded
+	TaskStateFailed
+	TaskStateStarted
+)
+
+// String returns the string representation of the TaskState.
+func (s TaskState) String() string {
+	switch s {
+	case TaskStateRunning:
+		return "Running"
+	case TaskStateSucceeded:
+		return "Succeeded"
+	case TaskStateFailed:
+		return "Failed"
+	case TaskStateStarted:
+		return "Started"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(s))
+}
+
+// Motion is a handle to a chassis motion started by a Chassis. It can be used
+// to wait for the motion to complete and to cancel it. It is thread safe.
+type Motion struct {
+	c      *Chassis
+	taskID uint8
+
+	m        sync.Mutex
+	percent  uint8
+	done     chan struct{}
+	finished bool
+	err      error
+}
+
+func newMotion(c *Chassis, taskID uint8) *Motion {
+	return &Motion{
+		c:      c,
+		taskID: taskID,
+		done:   make(chan struct{}),
+	}
+}
+
+// TaskID returns the robot task ID associated with this motion.
+func (m *Motion) TaskID() uint8 {
+	return m.taskID
+}
+
+// Progress returns the last reported completion percentage of this motion.
+func (m *Motion) Progress() uint8 {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.percent
+}
+
+// Done returns a channel that is closed when the motion completes, fails or
+// is canceled.
+func (m *Motion) Done() <-chan struct{} {
+	return m.done
+}
+
+// Err returns nil if the motion is still running or completed successfully.
+// Otherwise it returns the reason it did not complete.
+func (m *Motion) Err() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	return m.err
+}
+
+// Wait waits for the motion to complete. It returns nil if the motion
+// completed successfully, ErrCanceled if it was canceled or another error if
+// it failed. If the given context is done before that, the motion is
+// canceled and the context error is returned.
+func (m *Motion) Wait(ctx context.Context) error {
+	select {
+	case <-m.done:
+		return m.Err()
+	case <-ctx.Done():
+		m.Cancel()
+		return ctx.Err()
+	}
+}
+
+// Cancel cancels the motion if it is still running. If the cancel request
+// can not be sent, the motion is finished anyway (it will not be tracked
+// anymore, although the robot might still complete it) and the error is
+// returned and also reported by Err and Wait.
+func (m *Motion) Cancel() error {
+	m.m.Lock()
+	finished := m.finished
+	m.m.Unlock()
+
+	if finished {
+		return nil
+	}
+
+	err := m.c.cancel(m.taskID)
+	if err != nil {
+		err = fmt.Errorf("error canceling chassis task %d: %w", m.taskID, err)
+		m.finish(err)
+		return err
+	}
+
+	m.finish(ErrCanceled)
+
+	return nil
+}
+
+// update updates the motion with the given task state and progress. It
+// returns true if the motion finished.
+func (m *Motion) update(state TaskState, percent uint8) bool {
+	m.m.Lock()
+	m.percent = percent
+	m.m.Unlock()
+
+	switch state {
+	case TaskStateSucceeded:
+		m.finish(nil)
+	case TaskStateFailed:
+		m.finish(fmt.Errorf("chassis task %d failed", m.taskID))
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (m *Motion) finish(err error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if m.finished {
+		return
+	}
+
+	m.finished = true
+	m.err = err
+	close(m.done)
+
+	m.c.removeMotion(m.taskID)
+}
@@ -0,0 +1,153 @@
+package chassis
+
+import (
+	"context"
+	"sync"
+)
+
+// Waypoint is a position (x and y, in meters, and yaw, in degrees) relative
+// to the position of the previous waypoint.
+type Waypoint struct {
+	X   float32
+	Y   float32
+	Yaw float32
+}
+
+// Queue runs waypoint sequences by moving the chassis to one waypoint at a
+// time. It is thread safe.
+type Queue struct {
+	c *Chassis
+
+	m       sync.Mutex
+	pending []Waypoint
+	current *Motion
+	cleared uint64 // Incremented on every Clear() call.
+	running bool
+	idle    chan struct{}
+	err     error
+}
+
+// NewQueue returns a new, empty, motion queue for the given Chassis.
+func NewQueue(c *Chassis) *Queue {
+	idle := make(chan struct{})
+	close(idle)
+
+	return &Queue{
+		c:    c,
+		idle: idle,
+	}
+}
+
+// Add appends the given waypoints to the queue. The queue starts running
+// immediately if it is not already.
+func (q *Queue) Add(waypoints ...Waypoint) {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	q.pending = append(q.pending, waypoints...)
+
+	if !q.running && len(q.pending) > 0 {
+		q.running = true
+		q.idle = make(chan struct{})
+		q.err = nil
+
+		go q.run(q.idle)
+	}
+}
+
+// Len returns the number of waypoints still to be reached, including the one
+// currently being moved to.
+func (q *Queue) Len() int {
+	q.m.Lock()
+	defer q.m.Unlock()
+
+	n := len(q.pending)
+	if q.current != nil {
+		n++
+	}
+
+	return n
+}
+
+// Clear removes all pending waypoints and cancels the current motion, if any.
+func (q *Queue) Clear() error {
+	q.m.Lock()
+	q.pending = nil
+	q.cleared++
+	current := q.current
+	q.m.Unlock()
+
+	if current != nil {
+		return current.Cancel()
+	}
+
+	return nil
+}
+
+// Wait waits until all waypoints were reached. It returns the error of the
+// first motion that failed (in which case all pending waypoints are
+// discarded) or ErrCanceled if the queue was cleared. If the given context is
+// done before that, the queue is cleared and the context error is returned.
+func (q *Queue) Wait(ctx context.Context) error {
+	q.m.Lock()
+	idle := q.idle
+	q.m.Unlock()
+
+	select {
+	case <-idle:
+		q.m.Lock()
+		defer q.m.Unlock()
+
+		return q.err
+	case <-ctx.Done():
+		q.Clear()
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) run(idle chan struct{}) {
+	var err error
+
+	for {
+		q.m.Lock()
+
+		if err != nil || len(q.pending) == 0 {
+			q.pending = nil
+			q.current = nil
+			q.running = false
+			q.err = err
+			close(idle)
+			q.m.Unlock()
+			return
+		}
+
+		w := q.pending[0]
+		q.pending = q.pending[1:]
+		cleared := q.cleared
+
+		q.m.Unlock()
+
+		var m *Motion
+		m, err = q.c.MoveTo(w.X, w.Y, w.Yaw)
+		if err != nil {
+			continue
+		}
+
+		q.m.Lock()
+		q.current = m
+		stale := q.cleared != cleared
+		q.m.Unlock()
+
+		if stale {
+			// Cleared while the motion was being started.
+			m.Cancel()
+		}
+
+		<-m.Done()
+		err = m.Err()
+
+		q.m.Lock()
+		q.current = nil
+		q.m.Unlock()
+	}
+}
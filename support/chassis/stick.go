@@ -0,0 +1,43 @@
+package chassis
+
+import "math"
+
+// Virtual stick channels use the same encoding as the physical controller
+// sticks: 11 bits per channel, centered at stickCenter, with full deflection
+// at stickCenter +/- stickRange.
+const (
+	stickCenter = 1024
+	stickRange  = 660
+
+	stickChannelBits = 11
+
+	// Channel order in the packed value.
+	stickChannelLeftHorizontal  = 0 // Lateral (vy).
+	stickChannelLeftVertical    = 1 // Forward (vx).
+	stickChannelRightHorizontal = 2 // Rotation (wz).
+	stickChannelRightVertical   = 3 // Unused for chassis control.
+
+	// stickModeChassis selects chassis only control (the right stick does not
+	// move the gimbal).
+	stickModeChassis = 1
+)
+
+// stickValue returns the virtual stick channel value for the given stick
+// deflection (from -1 to 1). Out of range deflections are clamped.
+func stickValue(deflection float64) uint64 {
+	deflection = math.Max(-1, math.Min(1, deflection))
+
+	return uint64(math.Round(stickCenter + deflection*stickRange))
+}
+
+// packVirtualStick returns the KeyMainControllerVirtualStick value for the
+// given stick deflections (from -1 to 1).
+func packVirtualStick(forward, lateral, rotation float64) uint64 {
+	v := stickValue(lateral) << (stickChannelLeftHorizontal * stickChannelBits)
+	v |= stickValue(forward) << (stickChannelLeftVertical * stickChannelBits)
+	v |= stickValue(rotation) << (stickChannelRightHorizontal * stickChannelBits)
+	v |= stickValue(0) << (stickChannelRightVertical * stickChannelBits)
+	v |= stickModeChassis << (4 * stickChannelBits)
+
+	return v
+}
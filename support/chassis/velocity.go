@@ -0,0 +1,230 @@
+package chassis
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Velocity is a chassis body velocity.
+type Velocity struct {
+	X float64 // Forward speed, in m/s.
+	Y float64 // Lateral speed (right is positive), in m/s.
+	Z float64 // Rotation speed (clockwise is positive), in degrees/s.
+}
+
+// VelocityConfig configures a VelocityController.
+type VelocityConfig struct {
+	// Period between velocity commands sent to the robot.
+	Period time.Duration
+
+	// Speeds that correspond to full virtual stick deflection.
+	MaxLinearSpeed  float64 // m/s.
+	MaxAngularSpeed float64 // degrees/s.
+
+	// Maximum accelerations used to ramp velocity changes. Zero means
+	// changes are applied immediately.
+	MaxLinearAcceleration  float64 // m/s^2.
+	MaxAngularAcceleration float64 // degrees/s^2.
+
+	// If no velocity is set for this long, the controller stops the chassis
+	// immediately (without ramping).
+	FailsafeTimeout time.Duration
+}
+
+// DefaultVelocityConfig returns a VelocityConfig suitable for the Robomaster
+// S1 and EP chassis.
+func DefaultVelocityConfig() VelocityConfig {
+	return VelocityConfig{
+		Period:                 50 * time.Millisecond,
+		MaxLinearSpeed:         3.5,
+		MaxAngularSpeed:        600,
+		MaxLinearAcceleration:  4,
+		MaxAngularAcceleration: 900,
+		FailsafeTimeout:        500 * time.Millisecond,
+	}
+}
+
+// VelocityController continuously drives the chassis at the last velocity
+// set by callers using the virtual stick (KeyMainControllerVirtualStick).
+// Velocity updates are coalesced (only the latest one is sent), velocity
+// changes are ramped according to the configured accelerations and the
+// chassis is stopped if updates stop coming. It is thread safe.
+type VelocityController struct {
+	ub  unitybridge.UnityBridge
+	cfg VelocityConfig
+	l   *logger.Logger
+
+	m          sync.Mutex
+	target     Velocity
+	current    Velocity
+	lastUpdate time.Time
+	quit       chan struct{}
+	done       chan struct{}
+}
+
+// NewVelocityController returns a new VelocityController for the robot
+// connected to the given UnityBridge.
+func NewVelocityController(ub unitybridge.UnityBridge, cfg VelocityConfig,
+	l *logger.Logger) *VelocityController {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &VelocityController{
+		ub:  ub,
+		cfg: cfg,
+		l:   l.WithGroup("velocity_controller"),
+	}
+}
+
+// Start enables virtual stick mode and starts sending velocity commands.
+func (v *VelocityController) Start() error {
+	if v.cfg.Period <= 0 || v.cfg.MaxLinearSpeed <= 0 ||
+		v.cfg.MaxAngularSpeed <= 0 {
+		return fmt.Errorf("invalid velocity controller config: %+v", v.cfg)
+	}
+
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	if v.quit != nil {
+		return fmt.Errorf("velocity controller already started")
+	}
+
+	err := v.ub.SetKeyValueSync(key.KeyMainControllerVirtualStickEnabled,
+		&value.Bool{Value: true})
+	if err != nil {
+		return err
+	}
+
+	v.target = Velocity{}
+	v.current = Velocity{}
+	v.lastUpdate = time.Now()
+	v.quit = make(chan struct{})
+	v.done = make(chan struct{})
+
+	go v.loop(v.quit, v.done)
+
+	return nil
+}
+
+// Stop stops the chassis, stops sending velocity commands and disables
+// virtual stick mode.
+func (v *VelocityController) Stop() error {
+	v.m.Lock()
+
+	if v.quit == nil {
+		v.m.Unlock()
+		return fmt.Errorf("velocity controller not started")
+	}
+
+	close(v.quit)
+	done := v.done
+	v.quit = nil
+
+	v.m.Unlock()
+
+	<-done
+
+	err := v.ub.DirectSendKeyValue(key.KeyMainControllerVirtualStick,
+		packVirtualStick(0, 0, 0))
+	if err != nil {
+		return err
+	}
+
+	return v.ub.SetKeyValueSync(key.KeyMainControllerVirtualStickEnabled,
+		&value.Bool{Value: false})
+}
+
+// Set sets the velocity the chassis should move at. It must be called
+// periodically (more often than the configured failsafe timeout) or the
+// chassis will stop.
+func (v *VelocityController) Set(velocity Velocity) {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	v.target = velocity
+	v.lastUpdate = time.Now()
+}
+
+// Current returns the (ramped) velocity last sent to the robot.
+func (v *VelocityController) Current() Velocity {
+	v.m.Lock()
+	defer v.m.Unlock()
+
+	return v.current
+}
+
+func (v *VelocityController) loop(quit <-chan struct{},
+	done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(v.cfg.Period)
+	defer ticker.Stop()
+
+	last := time.Now()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case now := <-ticker.C:
+			if err := v.step(now.Sub(last), now); err != nil {
+				v.l.Error("Error sending velocity", "error", err)
+			}
+			last = now
+		}
+	}
+}
+
+// step ramps the current velocity towards the target velocity for the given
+// elapsed time (or sets it to zero if the failsafe timeout expired) and sends
+// it to the robot.
+func (v *VelocityController) step(elapsed time.Duration, now time.Time) error {
+	v.m.Lock()
+
+	if v.cfg.FailsafeTimeout > 0 &&
+		now.Sub(v.lastUpdate) > v.cfg.FailsafeTimeout {
+		// Updates stopped coming (the caller might be gone). Stop
+		// immediately instead of ramping down.
+		v.current = Velocity{}
+	} else {
+		dt := elapsed.Seconds()
+
+		v.current = Velocity{
+			X: ramp(v.current.X, v.target.X, v.cfg.MaxLinearAcceleration, dt),
+			Y: ramp(v.current.Y, v.target.Y, v.cfg.MaxLinearAcceleration, dt),
+			Z: ramp(v.current.Z, v.target.Z, v.cfg.MaxAngularAcceleration, dt),
+		}
+	}
+
+	current := v.current
+
+	v.m.Unlock()
+
+	return v.ub.DirectSendKeyValue(key.KeyMainControllerVirtualStick,
+		packVirtualStick(current.X/v.cfg.MaxLinearSpeed,
+			current.Y/v.cfg.MaxLinearSpeed, current.Z/v.cfg.MaxAngularSpeed))
+}
+
+// ramp returns the value closest to target that can be reached from current
+// in dt seconds with the given maximum acceleration. A non-positive
+// acceleration means no limit.
+func ramp(current, target, maxAcceleration, dt float64) float64 {
+	if maxAcceleration <= 0 {
+		return target
+	}
+
+	maxDelta := maxAcceleration * dt
+	delta := math.Max(-maxDelta, math.Min(maxDelta, target-current))
+
+	return current + delta
+}
@@ -0,0 +1,109 @@
+package chassis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackVirtualStick(t *testing.T) {
+	v := packVirtualStick(1, -1, 0.5)
+
+	channel := func(i int) uint64 {
+		return (v >> (i * stickChannelBits)) & (1<<stickChannelBits - 1)
+	}
+
+	assert.Equal(t, uint64(stickCenter-stickRange),
+		channel(stickChannelLeftHorizontal))
+	assert.Equal(t, uint64(stickCenter+stickRange),
+		channel(stickChannelLeftVertical))
+	assert.Equal(t, uint64(stickCenter+stickRange/2),
+		channel(stickChannelRightHorizontal))
+	assert.Equal(t, uint64(stickCenter), channel(stickChannelRightVertical))
+
+	// Out of range deflections are clamped.
+	assert.Equal(t, packVirtualStick(1, 0, 0), packVirtualStick(2, 0, 0))
+}
+
+func TestRamp(t *testing.T) {
+	assert.Equal(t, 0.5, ramp(0, 2, 10, 0.05))
+	assert.Equal(t, 1.5, ramp(2, 0, 10, 0.05))
+	assert.Equal(t, 2.0, ramp(1.9, 2, 10, 0.05))
+	assert.Equal(t, 2.0, ramp(0, 2, 0, 0.05))
+}
+
+func TestVelocityController(t *testing.T) {
+	ub := fakebridge.New()
+
+	cfg := DefaultVelocityConfig()
+	cfg.Period = 5 * time.Millisecond
+	cfg.MaxLinearAcceleration = 0
+	cfg.MaxAngularAcceleration = 0
+	cfg.FailsafeTimeout = 50 * time.Millisecond
+
+	v := NewVelocityController(ub, cfg, nil)
+	assert.NoError(t, v.Start())
+	assert.Error(t, v.Start())
+	assert.Equal(t, &value.Bool{Value: true},
+		ub.Value(key.KeyMainControllerVirtualStickEnabled))
+
+	// Only the latest velocity is used.
+	v.Set(Velocity{X: 1})
+	v.Set(Velocity{X: cfg.MaxLinearSpeed})
+
+	moving := packVirtualStick(1, 0, 0)
+	assert.Eventually(t, func() bool {
+		sent, _ := lastSent(ub)
+		return sent == moving
+	}, time.Second, time.Millisecond)
+
+	// Stops when updates stop coming.
+	stopped := packVirtualStick(0, 0, 0)
+	assert.Eventually(t, func() bool {
+		sent, _ := lastSent(ub)
+		return sent == stopped
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, Velocity{}, v.Current())
+
+	assert.NoError(t, v.Stop())
+
+	sent, n := lastSent(ub)
+	assert.Equal(t, stopped, sent)
+
+	time.Sleep(4 * cfg.Period)
+	_, after := lastSent(ub)
+	assert.Equal(t, n, after)
+
+	assert.Equal(t, &value.Bool{Value: false},
+		ub.Value(key.KeyMainControllerVirtualStickEnabled))
+}
+
+func TestVelocityController_Failsafe(t *testing.T) {
+	ub := fakebridge.New()
+
+	cfg := DefaultVelocityConfig()
+
+	v := NewVelocityController(ub, cfg, nil)
+
+	now := time.Now()
+
+	v.Set(Velocity{X: 1, Z: 90})
+	v.lastUpdate = now
+
+	// Velocity changes are ramped.
+	assert.NoError(t, v.step(100*time.Millisecond, now))
+	assert.InDelta(t, 0.4, v.Current().X, 1e-9)
+	assert.InDelta(t, 90, v.Current().Z, 1e-9)
+
+	// But the chassis is stopped immediately once the failsafe timeout
+	// expires.
+	assert.NoError(t, v.step(cfg.Period, now.Add(cfg.FailsafeTimeout+1)))
+	assert.Equal(t, Velocity{}, v.Current())
+
+	sent, _ := lastSent(ub)
+	assert.Equal(t, packVirtualStick(0, 0, 0), sent)
+}
@@ -0,0 +1,105 @@
+package support
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/brunoga/unitybridge/support/token"
+)
+
+// Dispatcher delivers values to a set of listeners. Values are delivered by a
+// single goroutine in the order they were dispatched, so dispatching never
+// blocks on listeners (and can be done while holding locks listeners might
+// need) and listeners always see values in order. It is thread safe.
+type Dispatcher[T any] struct {
+	tg *token.Generator
+
+	m         sync.Mutex
+	listeners map[token.Token]func(T)
+	pending   []T
+	draining  bool
+}
+
+// NewDispatcher returns a new Dispatcher instance.
+func NewDispatcher[T any]() *Dispatcher[T] {
+	return &Dispatcher[T]{
+		tg:        token.NewGenerator(),
+		listeners: make(map[token.Token]func(T)),
+	}
+}
+
+// AddListener adds a listener to be called for every dispatched value.
+// Returns a token that can be used to remove the listener later.
+func (d *Dispatcher[T]) AddListener(c func(T)) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	t := d.tg.Next()
+
+	d.m.Lock()
+	d.listeners[t] = c
+	d.m.Unlock()
+
+	return t, nil
+}
+
+// RemoveListener removes the listener associated with the given token.
+func (d *Dispatcher[T]) RemoveListener(t token.Token) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if _, ok := d.listeners[t]; !ok {
+		return fmt.Errorf("no listener registered with token %d", t)
+	}
+
+	delete(d.listeners, t)
+
+	return nil
+}
+
+// Dispatch queues the given value to be delivered to all listeners.
+func (d *Dispatcher[T]) Dispatch(v T) {
+	d.m.Lock()
+	defer d.m.Unlock()
+
+	if len(d.listeners) == 0 {
+		return
+	}
+
+	d.pending = append(d.pending, v)
+
+	if !d.draining {
+		d.draining = true
+		go d.drain()
+	}
+}
+
+func (d *Dispatcher[T]) drain() {
+	var zero T
+
+	for {
+		d.m.Lock()
+
+		if len(d.pending) == 0 {
+			d.draining = false
+			d.m.Unlock()
+			return
+		}
+
+		v := d.pending[0]
+		d.pending[0] = zero
+		d.pending = d.pending[1:]
+
+		callbacks := make([]func(T), 0, len(d.listeners))
+		for _, c := range d.listeners {
+			callbacks = append(callbacks, c)
+		}
+
+		d.m.Unlock()
+
+		for _, c := range callbacks {
+			c(v)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package support
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatcher_Order(t *testing.T) {
+	d := NewDispatcher[int]()
+
+	var m sync.Mutex
+	var got1, got2 []int
+
+	t1, err := d.AddListener(func(v int) {
+		m.Lock()
+		got1 = append(got1, v)
+		m.Unlock()
+	})
+	assert.NoError(t, err)
+
+	_, err = d.AddListener(func(v int) {
+		m.Lock()
+		got2 = append(got2, v)
+		m.Unlock()
+	})
+	assert.NoError(t, err)
+
+	want := make([]int, 1000)
+	for i := range want {
+		want[i] = i
+		d.Dispatch(i)
+	}
+
+	assert.Eventually(t, func() bool {
+		m.Lock()
+		defer m.Unlock()
+
+		return len(got1) == len(want) && len(got2) == len(want)
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, want, got1)
+	assert.Equal(t, want, got2)
+
+	assert.NoError(t, d.RemoveListener(t1))
+	assert.Error(t, d.RemoveListener(t1))
+
+	_, err = d.AddListener(nil)
+	assert.Error(t, err)
+}
+
+func TestDispatcher_DoesNotBlock(t *testing.T) {
+	d := NewDispatcher[int]()
+
+	release := make(chan struct{})
+	done := make(chan int, 2)
+
+	_, err := d.AddListener(func(v int) {
+		<-release
+		done <- v
+	})
+	assert.NoError(t, err)
+
+	d.Dispatch(1)
+	d.Dispatch(2)
+
+	close(release)
+
+	assert.Equal(t, 1, <-done)
+	assert.Equal(t, 2, <-done)
+}
@@ -0,0 +1,232 @@
+package finder
+
+import (
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge/support/logger"
+)
+
+const (
+	// DefaultBeaconTarget is the default address beacon broadcasts are sent
+	// to.
+	DefaultBeaconTarget = "255.255.255.255" + ipBroadcastAddrPort
+
+	// DefaultBeaconInterval is the default interval between beacon
+	// broadcasts.
+	DefaultBeaconInterval = 1 * time.Second
+)
+
+// BeaconOption is a configuration option for a Beacon.
+type BeaconOption func(b *Beacon)
+
+// WithBeaconTarget sets the address beacon broadcasts are sent to.
+func WithBeaconTarget(addr string) BeaconOption {
+	return func(b *Beacon) {
+		b.target = addr
+	}
+}
+
+// WithBeaconACKAddress sets the local address the beacon listens for ACK
+// messages on.
+func WithBeaconACKAddress(addr string) BeaconOption {
+	return func(b *Beacon) {
+		b.ackAddr = addr
+	}
+}
+
+// WithBeaconInterval sets the interval between beacon broadcasts. It must be
+// positive or Start fails.
+func WithBeaconInterval(interval time.Duration) BeaconOption {
+	return func(b *Beacon) {
+		b.interval = interval
+	}
+}
+
+// Beacon simulates a Robomaster robot broadcasting its presence in the
+// network. While in pairing mode, it also answers ACK messages (as sent by
+// Finder.SendACK) by leaving pairing mode and using the app ID in the ACK.
+// This allows testing discovery and pairing flows without an actual robot.
+//
+// Note that a Finder only accepts broadcasts with a source IP that matches the
+// address they were sent from. For loopback tests, the broadcast source IP
+// should be 127.0.0.1 and the target should be 127.0.0.1:45678 (or the port
+// set with WithPort).
+type Beacon struct {
+	target   string
+	ackAddr  string
+	interval time.Duration
+
+	l *logger.Logger
+
+	m       sync.Mutex
+	b       *Broadcast
+	conn    *net.UDPConn
+	ackConn *net.UDPConn
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewBeacon returns a new Beacon instance that sends the given broadcast.
+func NewBeacon(b *Broadcast, l *logger.Logger, opts ...BeaconOption) *Beacon {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	bc := &Beacon{
+		target:   DefaultBeaconTarget,
+		ackAddr:  listenerRemotePort,
+		interval: DefaultBeaconInterval,
+		l:        l.WithGroup("beacon"),
+		b:        b,
+	}
+
+	for _, opt := range opts {
+		opt(bc)
+	}
+
+	return bc
+}
+
+// Start starts sending broadcasts and listening for ACK messages. It returns
+// a non-nil error if the beacon is already started or its configuration is
+// invalid.
+func (bc *Beacon) Start() error {
+	bc.m.Lock()
+	defer bc.m.Unlock()
+
+	if bc.quit != nil {
+		return fmt.Errorf("beacon already started")
+	}
+
+	if bc.b == nil {
+		return fmt.Errorf("beacon broadcast cannot be nil")
+	}
+
+	if bc.interval <= 0 {
+		return fmt.Errorf("invalid beacon interval %s", bc.interval)
+	}
+
+	targetAddr, err := net.ResolveUDPAddr("udp4", bc.target)
+	if err != nil {
+		return err
+	}
+
+	bc.conn, err = net.DialUDP("udp4", nil, targetAddr)
+	if err != nil {
+		return err
+	}
+
+	bc.ackConn, err = listener(bc.ackAddr, nil)
+	if err != nil {
+		bc.conn.Close()
+		return err
+	}
+
+	bc.quit = make(chan struct{})
+
+	bc.wg.Add(2)
+	go bc.sendLoop(bc.conn, bc.quit)
+	go bc.ackLoop(bc.ackConn)
+
+	return nil
+}
+
+// Stop stops sending broadcasts and listening for ACK messages. It returns a
+// non-nil error if the beacon is not started.
+func (bc *Beacon) Stop() error {
+	bc.m.Lock()
+
+	if bc.quit == nil {
+		bc.m.Unlock()
+		return fmt.Errorf("beacon not started")
+	}
+
+	close(bc.quit)
+	bc.quit = nil
+
+	bc.conn.Close()
+	bc.ackConn.Close()
+
+	bc.m.Unlock()
+
+	bc.wg.Wait()
+
+	return nil
+}
+
+// Broadcast returns the broadcast currently being sent.
+func (bc *Beacon) Broadcast() *Broadcast {
+	bc.m.Lock()
+	defer bc.m.Unlock()
+
+	return bc.b
+}
+
+// SetBroadcast changes the broadcast being sent.
+func (bc *Beacon) SetBroadcast(b *Broadcast) {
+	bc.m.Lock()
+	defer bc.m.Unlock()
+
+	bc.b = b
+}
+
+func (bc *Beacon) sendLoop(conn *net.UDPConn, quit <-chan struct{}) {
+	defer bc.wg.Done()
+
+	ticker := time.NewTicker(bc.interval)
+	defer ticker.Stop()
+
+	for {
+		data, err := bc.Broadcast().Marshal()
+		if err != nil {
+			bc.l.Error("Error marshaling broadcast", "err", err)
+		} else if _, err = conn.Write(data); err != nil {
+			bc.l.Warn("Error sending broadcast", "err", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (bc *Beacon) ackLoop(conn *net.UDPConn) {
+	defer bc.wg.Done()
+
+	buf := make([]byte, 1024)
+
+	for {
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			// Connection closed.
+			return
+		}
+
+		if n != 8 {
+			bc.l.Warn("Unexpected ACK message length", "len", n, "addr", addr)
+			continue
+		}
+
+		appID := binary.LittleEndian.Uint64(buf[:n])
+
+		bc.l.Debug("Received ACK", "app_id", appID, "addr", addr)
+
+		bc.m.Lock()
+		if bc.b.IsPairing() {
+			bc.b = &Broadcast{
+				isPairing: false,
+				sourceIp:  bc.b.sourceIp,
+				sourceMac: bc.b.sourceMac,
+				appId:     appID,
+			}
+		}
+		bc.m.Unlock()
+	}
+}
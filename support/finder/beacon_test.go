@@ -0,0 +1,44 @@
+package finder
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeacon_StartStop(t *testing.T) {
+	bc := NewBeacon(newTestBroadcast(t, false, 1), nil,
+		WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", nettest.FreeUDPPort(t))),
+		WithBeaconACKAddress(fmt.Sprintf(":%d", nettest.FreeUDPPort(t))))
+
+	assert.Error(t, bc.Stop())
+	assert.NoError(t, bc.Start())
+	assert.Error(t, bc.Start())
+	assert.NoError(t, bc.Stop())
+	assert.Error(t, bc.Stop())
+}
+
+func TestBeacon_InvalidInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		bc := NewBeacon(newTestBroadcast(t, false, 1), nil,
+			WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d",
+				nettest.FreeUDPPort(t))),
+			WithBeaconACKAddress(fmt.Sprintf(":%d", nettest.FreeUDPPort(t))),
+			WithBeaconInterval(interval))
+
+		assert.Error(t, bc.Start())
+		assert.Error(t, bc.Stop())
+	}
+}
+
+func TestBeacon_NilBroadcast(t *testing.T) {
+	bc := NewBeacon(nil, nil,
+		WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", nettest.FreeUDPPort(t))),
+		WithBeaconACKAddress(fmt.Sprintf(":%d", nettest.FreeUDPPort(t))))
+
+	assert.Error(t, bc.Start())
+	assert.Error(t, bc.Stop())
+}
@@ -0,0 +1,14 @@
+package finder
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// bindToInterface binds the socket with the given file descriptor to the
+// given network interface.
+func bindToInterface(fd int, iface *net.Interface) error {
+	return unix.SetsockoptInt(fd, unix.IPPROTO_IP, unix.IP_BOUND_IF,
+		iface.Index)
+}
@@ -0,0 +1,15 @@
+package finder
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// bindToInterface binds the socket with the given file descriptor to the
+// given network interface. Older kernels (before 5.7) require CAP_NET_RAW for
+// this.
+func bindToInterface(fd int, iface *net.Interface) error {
+	return unix.SetsockoptString(fd, unix.SOL_SOCKET, unix.SO_BINDTODEVICE,
+		iface.Name)
+}
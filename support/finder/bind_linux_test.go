@@ -0,0 +1,27 @@
+package finder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func TestListener_BindsToInterface(t *testing.T) {
+	lo := loopbackInterface(t)
+
+	conn, err := listener(":0", lo)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	rc, err := conn.SyscallConn()
+	assert.NoError(t, err)
+
+	var device string
+	assert.NoError(t, rc.Control(func(fd uintptr) {
+		device, err = unix.GetsockoptString(int(fd), unix.SOL_SOCKET,
+			unix.SO_BINDTODEVICE)
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, lo.Name, device)
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !windows
+
+package finder
+
+import (
+	"net"
+)
+
+// bindToInterface does nothing as binding sockets to network interfaces is
+// not supported in this platform. Broadcasts arriving from other interfaces
+// are still ignored by the Finder.
+func bindToInterface(fd int, iface *net.Interface) error {
+	return nil
+}
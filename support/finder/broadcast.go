@@ -23,6 +23,32 @@ type Broadcast struct {
 	sourceIp  net.IP
 	sourceMac net.HardwareAddr
 	appId     uint64
+
+	// Only set for broadcasts received by a Finder.
+	iface   *net.Interface
+	localIp net.IP
+}
+
+// NewBroadcast creates a new Broadcast instance with the given parameters.
+// The source IP must be an IPv4 address and the source MAC must have exactly
+// 6 octets.
+func NewBroadcast(isPairing bool, sourceIp net.IP,
+	sourceMac net.HardwareAddr, appId uint64) (*Broadcast, error) {
+	ip := sourceIp.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("source IP must be an IPv4 address")
+	}
+
+	if len(sourceMac) != 6 {
+		return nil, fmt.Errorf("source MAC must have exactly 6 octets")
+	}
+
+	return &Broadcast{
+		isPairing: isPairing,
+		sourceIp:  bytes.Clone(ip),
+		sourceMac: bytes.Clone(sourceMac),
+		appId:     appId,
+	}, nil
 }
 
 // ParseBroadcast parses the given data as a BroadcastMessage. It
@@ -43,19 +69,50 @@ func ParseBroadcast(data []byte) (*Broadcast, error) {
 	// First byte tells us if this is a pairing message.
 	isPairing := (data[2] & 1) > 0
 
-	// Then we get the rest of the data trivially.
-	sourceIp := data[6:10]
-	sourceMac := data[10:16]
+	// Then we get the rest of the data trivially. Slices are cloned so the
+	// broadcast does not depend on the given buffer being kept unchanged.
+	sourceIp := net.IP(bytes.Clone(data[6:10]))
+	sourceMac := net.HardwareAddr(bytes.Clone(data[10:16]))
 	appId := binary.LittleEndian.Uint64(data[16:])
 
 	return &Broadcast{
-		isPairing,
-		sourceIp,
-		sourceMac,
-		appId,
+		isPairing: isPairing,
+		sourceIp:  sourceIp,
+		sourceMac: sourceMac,
+		appId:     appId,
 	}, nil
 }
 
+// Marshal encodes this Broadcast as a broadcast message like the ones sent by
+// a Robomaster robot. This is the inverse of ParseBroadcast.
+func (b *Broadcast) Marshal() ([]byte, error) {
+	ip := b.sourceIp.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("source IP must be an IPv4 address")
+	}
+
+	if len(b.sourceMac) != 6 {
+		return nil, fmt.Errorf("source MAC must have exactly 6 octets")
+	}
+
+	data := make([]byte, broadcastLen)
+
+	copy(data, broadcastHeader)
+
+	if b.isPairing {
+		data[2] |= 1
+	}
+
+	copy(data[6:10], ip)
+	copy(data[10:16], b.sourceMac)
+	binary.LittleEndian.PutUint64(data[16:], b.appId)
+
+	// Encode outgoing data.
+	support.SimpleEncryptDecrypt(data)
+
+	return data, nil
+}
+
 func (b *Broadcast) IsPairing() bool {
 	return b.isPairing
 }
@@ -72,7 +129,34 @@ func (b *Broadcast) AppId() uint64 {
 	return b.appId
 }
 
+// Interface returns the local network interface this broadcast arrived on. It
+// returns nil if the interface is unknown.
+func (b *Broadcast) Interface() *net.Interface {
+	return b.iface
+}
+
+// LocalIp returns the local IP address, in the interface this broadcast
+// arrived on, that is in the same network as the robot. It returns nil if the
+// interface is unknown.
+func (b *Broadcast) LocalIp() net.IP {
+	return b.localIp
+}
+
 func (b *Broadcast) String() string {
-	return fmt.Sprintf("IsPairing:%t, SourceIp:%s, SourceMac:%s, AppId:%d",
-		b.isPairing, b.sourceIp, b.sourceMac, b.appId)
+	ifaceName := ""
+	if b.iface != nil {
+		ifaceName = b.iface.Name
+	}
+
+	return fmt.Sprintf("IsPairing:%t, SourceIp:%s, SourceMac:%s, AppId:%d, "+
+		"Interface:%s", b.isPairing, b.sourceIp, b.sourceMac, b.appId,
+		ifaceName)
+}
+
+// Equal returns true if the given broadcast carries the same data as this one.
+func (b *Broadcast) Equal(other *Broadcast) bool {
+	return b.isPairing == other.isPairing &&
+		b.sourceIp.Equal(other.sourceIp) &&
+		bytes.Equal(b.sourceMac, other.sourceMac) &&
+		b.appId == other.appId
 }
@@ -0,0 +1,80 @@
+package finder
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBroadcast_InvalidParameters(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	_, err := NewBroadcast(false, net.ParseIP("::1"), mac, 1)
+	assert.Error(t, err)
+
+	_, err = NewBroadcast(false, net.ParseIP("192.168.1.2"), mac[:4], 1)
+	assert.Error(t, err)
+}
+
+func TestBroadcastMarshal_RoundTrip(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	for _, isPairing := range []bool{false, true} {
+		b, err := NewBroadcast(isPairing, net.ParseIP("192.168.1.2"), mac,
+			0x0123456789abcdef)
+		assert.NoError(t, err)
+
+		data, err := b.Marshal()
+		assert.NoError(t, err)
+		assert.Len(t, data, broadcastLen)
+
+		parsed, err := ParseBroadcast(data)
+		assert.NoError(t, err)
+
+		assert.True(t, b.Equal(parsed))
+		assert.Equal(t, isPairing, parsed.IsPairing())
+		assert.Equal(t, "192.168.1.2", parsed.SourceIp().String())
+		assert.Equal(t, mac, parsed.SourceMac())
+		assert.Equal(t, uint64(0x0123456789abcdef), parsed.AppId())
+	}
+}
+
+func TestBroadcastMarshal_ZeroValue(t *testing.T) {
+	_, err := (&Broadcast{}).Marshal()
+	assert.Error(t, err)
+}
+
+func TestBeacon_FindAndPair(t *testing.T) {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	b, err := NewBroadcast(true, net.IPv4(127, 0, 0, 1), mac, 0)
+	assert.NoError(t, err)
+
+	port := nettest.FreeUDPPort(t)
+	ackPort := nettest.FreeUDPPort(t)
+
+	bc := NewBeacon(b, nil,
+		WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", port)),
+		WithBeaconACKAddress(fmt.Sprintf(":%d", ackPort)),
+		WithBeaconInterval(100*time.Millisecond))
+	err = bc.Start()
+	assert.NoError(t, err)
+	defer bc.Stop()
+
+	found, err := New(0, nil, WithPort(port)).Find(5 * time.Second)
+	assert.NoError(t, err)
+	assert.True(t, found.IsPairing())
+	assert.Equal(t, mac, found.SourceMac())
+
+	err = New(0, nil, WithACKPort(ackPort)).SendACK(found.SourceIp(), 1234)
+	assert.NoError(t, err)
+
+	found, err = New(1234, nil, WithPort(port)).Find(5 * time.Second)
+	assert.NoError(t, err)
+	assert.False(t, found.IsPairing())
+	assert.Equal(t, uint64(1234), found.AppId())
+}
@@ -5,49 +5,124 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/brunoga/unitybridge/support"
 	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/token"
+	"golang.org/x/net/ipv4"
 )
 
 const (
 	ipBroadcastAddrPort = ":45678"
 	listenerRemotePort  = ":56789"
+
+	// DefaultExpiry is the default amount of time after which a robot that
+	// was not seen is considered lost.
+	DefaultExpiry = 10 * time.Second
 )
 
+// Option is a configuration option for a Finder.
+type Option func(f *Finder)
+
+// WithExpiry sets the amount of time after which a robot that was not seen is
+// considered lost. If expiry is zero or negative, robots are never considered
+// lost.
+func WithExpiry(expiry time.Duration) Option {
+	return func(f *Finder) {
+		f.expiry = expiry
+	}
+}
+
+// WithPort sets the UDP port robot broadcasts are listened for on. It is only
+// useful for tests (see Beacon) as robots always broadcast to port 45678.
+func WithPort(port int) Option {
+	return func(f *Finder) {
+		f.listenAddr = ":" + strconv.Itoa(port)
+	}
+}
+
+// WithACKPort sets the UDP port ACK messages are sent to. It is only useful
+// for tests (see Beacon) as robots always listen for ACKs on port 56789.
+func WithACKPort(port int) Option {
+	return func(f *Finder) {
+		f.ackPort = ":" + strconv.Itoa(port)
+	}
+}
+
+// WithInterface restricts robot discovery to the network interface with the
+// given name. The sockets used for receiving broadcasts and sending ACKs are
+// bound to the interface, so broadcasts arriving from other interfaces are not
+// received and ACKs always leave through it.
+func WithInterface(name string) Option {
+	return func(f *Finder) {
+		f.ifaceName = name
+	}
+}
+
+// WithAddress restricts robot discovery to the network interface that has
+// the given local IP address assigned to it. See WithInterface.
+func WithAddress(ip net.IP) Option {
+	return func(f *Finder) {
+		f.addr = ip
+	}
+}
+
 // Finder provides an interface for finding a robot broadcasting its ip in
 // the network.
 type Finder struct {
-	appID uint64
+	appID      uint64
+	expiry     time.Duration
+	listenAddr string
+	ackPort    string
+	ifaceName  string
+	addr       net.IP
 
 	l *logger.Logger
+	d *support.Dispatcher[*PresenceEvent]
 
 	m             sync.Mutex
-	listeningConn *net.UDPConn
+	iface         *net.Interface
+	listeningConn *ipv4.PacketConn
 	quit          chan struct{}
-	broadcasts    map[string]*Broadcast
+	robots        map[string]*Robot
+	wg            sync.WaitGroup
 }
 
 // New returns a new Finder instance. If appID is zero, consider any robots
 // detected in the network regardless of their pairing status or appID. If appID
 // is non-zero, returns only robots with the given appID and that are not in
 // pairing mode.
-func New(appID uint64, l *logger.Logger) *Finder {
+func New(appID uint64, l *logger.Logger, opts ...Option) *Finder {
 	if l == nil {
 		l = logger.New(slog.LevelError)
 	}
 
-	return &Finder{
+	f := &Finder{
 		appID:      appID,
+		expiry:     DefaultExpiry,
+		listenAddr: ipBroadcastAddrPort,
+		ackPort:    listenerRemotePort,
 		l:          l,
+		d:          support.NewDispatcher[*PresenceEvent](),
 		quit:       nil,
-		broadcasts: nil,
+		robots:     make(map[string]*Robot),
+	}
+
+	for _, opt := range opts {
+		opt(f)
 	}
+
+	return f
 }
 
 // StartFinding starts listening for Robomaster broadcast messages in the
-// network. It returns a non-nil error if it is already looking for robots.
+// network. The broadcast for each robot is sent to the given channel when it
+// appears and whenever its broadcast data changes (for example, when its IP
+// address changes or it leaves pairing mode). It returns a non-nil error if it
+// is already looking for robots.
 func (f *Finder) StartFinding(ch chan<- *Broadcast) error {
 	f.m.Lock()
 	defer f.m.Unlock()
@@ -56,39 +131,91 @@ func (f *Finder) StartFinding(ch chan<- *Broadcast) error {
 		return fmt.Errorf("already finding")
 	}
 
-	f.quit = make(chan struct{})
-	f.broadcasts = make(map[string]*Broadcast)
-
 	var err error
-	f.listeningConn, err = listener(ipBroadcastAddrPort)
+	f.iface, err = f.resolveInterface()
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		f.findLoop(ch)
-	}()
+	conn, err := listener(f.listenAddr, f.iface)
+	if err != nil {
+		return err
+	}
+
+	f.listeningConn = ipv4.NewPacketConn(conn)
+
+	// Used to find out the interface broadcasts arrive on.
+	err = f.listeningConn.SetControlMessage(ipv4.FlagInterface, true)
+	if err != nil {
+		f.l.Debug("Arrival interface not available, guessing it from "+
+			"broadcast addresses", "err", err)
+	}
+
+	f.quit = make(chan struct{})
+	f.robots = make(map[string]*Robot)
+
+	f.wg.Add(1)
+	go f.findLoop(ch, f.quit)
 
 	return nil
 }
 
 // StopFinding stops listening for Robomaster broadcast messages in the
-// network. It returns a non-nil error if it is not currently looking for
-// robots.
+// network. Lost events are generated for all robots currently seen. It
+// returns a non-nil error if it is not currently looking for robots.
 func (f *Finder) StopFinding() error {
 	f.m.Lock()
-	defer f.m.Unlock()
 
 	if f.quit == nil {
+		f.m.Unlock()
 		return fmt.Errorf("not finding")
 	}
 
 	close(f.quit)
 	f.listeningConn.Close()
 
+	f.m.Unlock()
+
+	f.wg.Wait()
+
 	return nil
 }
 
+// Robots returns a snapshot of all robots currently seen in the network.
+func (f *Finder) Robots() []*Robot {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	robots := make([]*Robot, 0, len(f.robots))
+	for _, r := range f.robots {
+		snapshot := *r
+		robots = append(robots, &snapshot)
+	}
+
+	return robots
+}
+
+// AddPresenceListener adds a listener for presence events. Events are
+// delivered in the order they were generated. Returns a token that can be used
+// to remove the listener later.
+func (f *Finder) AddPresenceListener(c PresenceCallback) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	return f.d.AddListener(c)
+}
+
+// RemovePresenceListener removes the listener associated with the given
+// token.
+func (f *Finder) RemovePresenceListener(t token.Token) error {
+	if t == 0 {
+		return fmt.Errorf("token cannot be 0")
+	}
+
+	return f.d.RemoveListener(t)
+}
+
 // Find waits for a robot to broadcast its IP address in the network. It
 // returns a non-nil error if no robot is found in the given timeout.
 func (f *Finder) Find(timeout time.Duration) (*Broadcast, error) {
@@ -109,42 +236,84 @@ func (f *Finder) Find(timeout time.Duration) (*Broadcast, error) {
 }
 
 // SendACK sends an ACK message to the given IP address. This is used to
-// acknowledge a pairing request.
-func (f *Finder) SendACK(ip net.IP, appID uint64) {
+// acknowledge a pairing request. The message is sent from the local interface
+// connected to the network the given IP is in (or from the configured
+// interface, if any). It returns a non-nil error if the ACK could not be sent.
+func (f *Finder) SendACK(ip net.IP, appID uint64) error {
 	buffer := make([]byte, 8)
 	binary.LittleEndian.PutUint64(buffer, appID)
 
-	udpAddr, err := net.ResolveUDPAddr("udp4", ip.String()+listenerRemotePort)
+	udpAddr, err := net.ResolveUDPAddr("udp4", ip.String()+f.ackPort)
 	if err != nil {
-		return
+		return err
 	}
 
-	conn, err := net.DialUDP("udp4", nil, udpAddr)
+	iface, err := f.resolveInterface()
 	if err != nil {
-		return
+		return err
+	}
+
+	var localAddr *net.UDPAddr
+	if _, localIp, err := localAddressFor(ip, iface); err == nil {
+		localAddr = &net.UDPAddr{IP: localIp}
+	} else if iface != nil {
+		localIp, err := interfaceIPv4(iface)
+		if err != nil {
+			return err
+		}
+
+		localAddr = &net.UDPAddr{IP: localIp}
+	}
+
+	conn, err := dialer(localAddr, iface).Dial("udp4", udpAddr.String())
+	if err != nil {
+		return err
 	}
 	defer conn.Close()
 
 	_, err = conn.Write(buffer)
-	if err != nil {
-		return
+
+	return err
+}
+
+// resolveInterface returns the network interface discovery is restricted to
+// or nil if there is no such restriction.
+func (f *Finder) resolveInterface() (*net.Interface, error) {
+	switch {
+	case f.ifaceName != "":
+		return net.InterfaceByName(f.ifaceName)
+	case f.addr != nil:
+		return interfaceByAddress(f.addr)
+	default:
+		return nil, nil
 	}
 }
 
-func (f *Finder) findLoop(ch chan<- *Broadcast) {
+func (f *Finder) findLoop(ch chan<- *Broadcast, quit <-chan struct{}) {
+	defer f.wg.Done()
+
 	f.l.Debug("Starting to look for robots")
 	defer f.l.Debug("Stopped looking for robots")
 
+	// Robots are expired between reads, so make sure reads do not block for
+	// much longer than the expiry.
+	readTimeout := 1 * time.Second
+	if f.expiry > 0 && f.expiry/2 < readTimeout {
+		readTimeout = f.expiry / 2
+	}
+
 	buf := make([]byte, 1024)
 
 L:
 	for {
+		f.expireRobots(time.Now())
+
 		select {
-		case <-f.quit:
+		case <-quit:
 			break L
 		default:
-			f.listeningConn.SetReadDeadline(time.Now().Add(1 * time.Second))
-			n, addr, err := f.listeningConn.ReadFromUDP(buf)
+			f.listeningConn.SetReadDeadline(time.Now().Add(readTimeout))
+			n, cm, addr, err := f.listeningConn.ReadFrom(buf)
 			if err != nil {
 				if opErr, ok := err.(*net.OpError); ok {
 					if opErr.Timeout() {
@@ -161,22 +330,119 @@ L:
 				continue
 			}
 
+			broadcast.iface, broadcast.localIp, err = arrivalAddress(cm,
+				broadcast.SourceIp())
+			if err != nil {
+				f.l.Warn("error finding broadcast interface", "err", err)
+			}
+
+			// Only needed where sockets can not be bound to interfaces.
+			if f.iface != nil && (broadcast.iface == nil ||
+				broadcast.iface.Index != f.iface.Index) {
+				f.l.Debug("Ignoring broadcast from another interface",
+					"broadcast", broadcast)
+				continue
+			}
+
 			f.l.Debug("Received broadcast message", "broadcast", broadcast)
 
 			if f.appID == 0 || (broadcast.AppId() == f.appID) {
-				_, ok := f.broadcasts[broadcast.SourceIp().String()]
-				if !ok {
-					f.broadcasts[broadcast.SourceIp().String()] = broadcast
-					ch <- broadcast
+				if f.updateRobot(broadcast, time.Now()) {
+					select {
+					case ch <- broadcast:
+					case <-quit:
+						break L
+					}
 				}
 			}
 		}
 	}
 
 	f.m.Lock()
+
+	for _, r := range f.robots {
+		f.notifyListenersLocked(PresenceEventTypeLost, r)
+	}
+
 	f.quit = nil
+	f.iface = nil
 	f.listeningConn = nil
+	f.robots = make(map[string]*Robot)
+
+	f.m.Unlock()
+}
+
+// updateRobot updates the presence information for the robot that sent the
+// given broadcast, generating any relevant events. It returns true if the
+// robot just appeared or if its broadcast data changed.
+func (f *Finder) updateRobot(b *Broadcast, now time.Time) bool {
+	id := b.SourceMac().String()
+
+	f.m.Lock()
+
+	r, ok := f.robots[id]
+	if !ok {
+		r = &Robot{
+			broadcast: b,
+			firstSeen: now,
+			lastSeen:  now,
+		}
+		f.robots[id] = r
+
+		f.notifyListenersLocked(PresenceEventTypeAppeared, r)
+
+		f.m.Unlock()
+
+		return true
+	}
+
+	r.lastSeen = now
+
+	if r.broadcast.Equal(b) {
+		f.m.Unlock()
+		return false
+	}
+
+	r.broadcast = b
+
+	f.notifyListenersLocked(PresenceEventTypeUpdated, r)
+
 	f.m.Unlock()
+
+	return true
+}
+
+// expireRobots removes all robots that were not seen for longer than the
+// configured expiry, generating the relevant events.
+func (f *Finder) expireRobots(now time.Time) {
+	if f.expiry <= 0 {
+		return
+	}
+
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	for id, r := range f.robots {
+		if now.Sub(r.lastSeen) > f.expiry {
+			delete(f.robots, id)
+
+			f.l.Debug("Robot lost", "robot", r)
+
+			f.notifyListenersLocked(PresenceEventTypeLost, r)
+		}
+	}
+}
+
+// notifyListenersLocked notifies all presence listeners about the given event
+// type for the given robot. The finder mutex must be locked when this is
+// called.
+func (f *Finder) notifyListenersLocked(typ PresenceEventType, r *Robot) {
+	ev := &PresenceEvent{
+		typ:   typ,
+		robot: *r,
+	}
+
+	f.d.Dispatch(ev)
 }
 
 func parseAndValidateBroadcast(buf []byte, addr net.Addr) (*Broadcast, error) {
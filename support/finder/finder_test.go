@@ -0,0 +1,160 @@
+package finder
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBroadcast(t *testing.T, isPairing bool, appID uint64) *Broadcast {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	b, err := NewBroadcast(isPairing, net.IPv4(127, 0, 0, 1), mac, appID)
+	assert.NoError(t, err)
+
+	return b
+}
+
+// startTestBeacon starts a loopback beacon sending the given broadcast to the
+// given port.
+func startTestBeacon(t *testing.T, b *Broadcast, port int) *Beacon {
+	bc := NewBeacon(b, nil,
+		WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", port)),
+		WithBeaconACKAddress(fmt.Sprintf(":%d", nettest.FreeUDPPort(t))),
+		WithBeaconInterval(10*time.Millisecond))
+	assert.NoError(t, bc.Start())
+
+	return bc
+}
+
+func nextPresenceEvent(t *testing.T, ch chan *PresenceEvent) *PresenceEvent {
+	t.Helper()
+
+	select {
+	case ev := <-ch:
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no presence event")
+	}
+
+	return nil
+}
+
+func TestFinder_Presence(t *testing.T) {
+	port := nettest.FreeUDPPort(t)
+
+	f := New(0, nil, WithPort(port), WithExpiry(100*time.Millisecond))
+
+	ch := make(chan *PresenceEvent, 10)
+	tk, err := f.AddPresenceListener(func(ev *PresenceEvent) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	broadcasts := make(chan *Broadcast, 10)
+	assert.NoError(t, f.StartFinding(broadcasts))
+	assert.Error(t, f.StartFinding(broadcasts))
+	defer f.StopFinding()
+
+	bc := startTestBeacon(t, newTestBroadcast(t, true, 0), port)
+
+	ev := nextPresenceEvent(t, ch)
+	assert.Equal(t, PresenceEventTypeAppeared, ev.Type())
+	assert.True(t, ev.Robot().Broadcast().IsPairing())
+	assert.True(t, (<-broadcasts).IsPairing())
+	assert.Len(t, f.Robots(), 1)
+
+	bc.SetBroadcast(newTestBroadcast(t, false, 1))
+
+	ev = nextPresenceEvent(t, ch)
+	assert.Equal(t, PresenceEventTypeUpdated, ev.Type())
+	assert.Equal(t, uint64(1), ev.Robot().Broadcast().AppId())
+	assert.Equal(t, uint64(1), (<-broadcasts).AppId())
+
+	// Robots are lost once they stop broadcasting.
+	assert.NoError(t, bc.Stop())
+
+	ev = nextPresenceEvent(t, ch)
+	assert.Equal(t, PresenceEventTypeLost, ev.Type())
+	assert.Empty(t, f.Robots())
+
+	assert.NoError(t, f.RemovePresenceListener(tk))
+	assert.Error(t, f.RemovePresenceListener(tk))
+}
+
+func TestFinder_StopFindingLosesRobots(t *testing.T) {
+	port := nettest.FreeUDPPort(t)
+
+	f := New(0, nil, WithPort(port))
+
+	ch := make(chan *PresenceEvent, 10)
+	_, err := f.AddPresenceListener(func(ev *PresenceEvent) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, f.StartFinding(make(chan *Broadcast, 10)))
+
+	bc := startTestBeacon(t, newTestBroadcast(t, false, 1), port)
+	defer bc.Stop()
+
+	assert.Equal(t, PresenceEventTypeAppeared, nextPresenceEvent(t, ch).Type())
+
+	assert.NoError(t, f.StopFinding())
+
+	assert.Equal(t, PresenceEventTypeLost, nextPresenceEvent(t, ch).Type())
+	assert.Empty(t, f.Robots())
+}
+
+func TestFinder_Find(t *testing.T) {
+	port := nettest.FreeUDPPort(t)
+
+	f := New(1, nil, WithPort(port))
+
+	_, err := f.Find(50 * time.Millisecond)
+	assert.Error(t, err)
+
+	// Robots with other app IDs are ignored.
+	other := startTestBeacon(t, newTestBroadcast(t, false, 2), port)
+	defer other.Stop()
+
+	bc := startTestBeacon(t, newTestBroadcast(t, false, 1), port)
+	defer bc.Stop()
+
+	b, err := f.Find(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), b.AppId())
+}
+
+func TestFinder_ExpireRobots(t *testing.T) {
+	f := New(0, nil, WithExpiry(time.Second))
+
+	ch := make(chan *PresenceEvent, 10)
+	_, err := f.AddPresenceListener(func(ev *PresenceEvent) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	b := newTestBroadcast(t, false, 1)
+
+	assert.True(t, f.updateRobot(b, now))
+	assert.False(t, f.updateRobot(b, now.Add(time.Second)))
+
+	// Not expired yet as the robot was seen again.
+	f.expireRobots(now.Add(1500 * time.Millisecond))
+	assert.Len(t, f.Robots(), 1)
+	assert.Equal(t, now.Add(time.Second), f.Robots()[0].LastSeen())
+	assert.Equal(t, now, f.Robots()[0].FirstSeen())
+
+	f.expireRobots(now.Add(2001 * time.Millisecond))
+	assert.Empty(t, f.Robots())
+
+	assert.Equal(t, PresenceEventTypeAppeared, nextPresenceEvent(t, ch).Type())
+	assert.Equal(t, PresenceEventTypeLost, nextPresenceEvent(t, ch).Type())
+}
@@ -0,0 +1,121 @@
+package finder
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/net/ipv4"
+)
+
+// interfaceByAddress returns the network interface that has the given IP
+// address assigned to it.
+func interfaceByAddress(ip net.IP) (*net.Interface, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ifaces {
+		addrs, err := ifaces[i].Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if ok && ipNet.IP.Equal(ip) {
+				return &ifaces[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no interface with address %s", ip)
+}
+
+// localAddressFor returns the network interface and the local IPv4 address
+// directly connected to the network the given remote IP is in. If iface is
+// non-nil, only that interface is considered.
+func localAddressFor(remote net.IP, iface *net.Interface) (*net.Interface,
+	net.IP, error) {
+	var ifaces []net.Interface
+	if iface != nil {
+		ifaces = []net.Interface{*iface}
+	} else {
+		var err error
+		ifaces, err = net.Interfaces()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagUp == 0 {
+			continue
+		}
+
+		addrs, err := ifaces[i].Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok || ipNet.IP.To4() == nil {
+				continue
+			}
+
+			if ipNet.Contains(remote) {
+				return &ifaces[i], ipNet.IP.To4(), nil
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no local interface connected to %s", remote)
+}
+
+// interfaceIPv4 returns the first IPv4 address assigned to the given network
+// interface.
+func interfaceIPv4(iface *net.Interface) (net.IP, error) {
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if ok && ipNet.IP.To4() != nil {
+			return ipNet.IP.To4(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("no IPv4 address in interface %s", iface.Name)
+}
+
+// arrivalAddress returns the network interface a packet from the given remote
+// IP arrived on, as reported by the given control message, and the local IPv4
+// address in it that is in the same network as the remote IP (or its first
+// IPv4 address if there is none). If the control message does not report an
+// interface (control messages are not supported in all platforms), the
+// interface is guessed from the remote IP.
+func arrivalAddress(cm *ipv4.ControlMessage, remote net.IP) (*net.Interface,
+	net.IP, error) {
+	if cm == nil || cm.IfIndex == 0 {
+		return localAddressFor(remote, nil)
+	}
+
+	iface, err := net.InterfaceByIndex(cm.IfIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, localIp, err := localAddressFor(remote, iface); err == nil {
+		return iface, localIp, nil
+	}
+
+	localIp, err := interfaceIPv4(iface)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return iface, localIp, nil
+}
@@ -0,0 +1,120 @@
+package finder
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/ipv4"
+)
+
+func loopbackInterface(t *testing.T) *net.Interface {
+	t.Helper()
+
+	ifaces, err := net.Interfaces()
+	assert.NoError(t, err)
+
+	for i := range ifaces {
+		if ifaces[i].Flags&net.FlagLoopback != 0 {
+			return &ifaces[i]
+		}
+	}
+
+	t.Skip("no loopback interface")
+
+	return nil
+}
+
+func TestInterfaceByAddress(t *testing.T) {
+	lo := loopbackInterface(t)
+
+	iface, err := interfaceByAddress(net.IPv4(127, 0, 0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, lo.Name, iface.Name)
+
+	_, err = interfaceByAddress(net.IPv4(198, 51, 100, 1))
+	assert.Error(t, err)
+}
+
+func TestArrivalAddress(t *testing.T) {
+	lo := loopbackInterface(t)
+
+	// Guessed from the remote address.
+	iface, localIp, err := arrivalAddress(nil, net.IPv4(127, 0, 0, 5))
+	assert.NoError(t, err)
+	assert.Equal(t, lo.Name, iface.Name)
+	assert.Equal(t, "127.0.0.1", localIp.String())
+
+	_, _, err = arrivalAddress(nil, net.IPv4(198, 51, 100, 1))
+	assert.Error(t, err)
+
+	// Reported by the control message, even if the remote address is not in
+	// any of the interface networks.
+	iface, localIp, err = arrivalAddress(&ipv4.ControlMessage{
+		IfIndex: lo.Index,
+	}, net.IPv4(198, 51, 100, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, lo.Name, iface.Name)
+	assert.Equal(t, "127.0.0.1", localIp.String())
+}
+
+func TestFinder_WithInterface(t *testing.T) {
+	lo := loopbackInterface(t)
+
+	for _, opt := range []Option{
+		WithInterface(lo.Name),
+		WithAddress(net.IPv4(127, 0, 0, 1)),
+	} {
+		port := nettest.FreeUDPPort(t)
+
+		f := New(0, nil, WithPort(port), opt)
+
+		broadcasts := make(chan *Broadcast, 10)
+		assert.NoError(t, f.StartFinding(broadcasts))
+
+		bc := startTestBeacon(t, newTestBroadcast(t, false, 1), port)
+
+		select {
+		case b := <-broadcasts:
+			assert.Equal(t, lo.Name, b.Interface().Name)
+			assert.Equal(t, "127.0.0.1", b.LocalIp().String())
+		case <-time.After(time.Second):
+			t.Error("no broadcast")
+		}
+
+		assert.NoError(t, bc.Stop())
+		assert.NoError(t, f.StopFinding())
+	}
+
+	f := New(0, nil, WithInterface("nonexistent0"))
+	assert.Error(t, f.StartFinding(make(chan *Broadcast)))
+
+	f = New(0, nil, WithAddress(net.IPv4(198, 51, 100, 1)))
+	assert.Error(t, f.StartFinding(make(chan *Broadcast)))
+}
+
+func TestFinder_SendACK(t *testing.T) {
+	lo := loopbackInterface(t)
+
+	ackPort := nettest.FreeUDPPort(t)
+
+	bc := NewBeacon(newTestBroadcast(t, true, 0), nil,
+		WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", nettest.FreeUDPPort(t))),
+		WithBeaconACKAddress(fmt.Sprintf(":%d", ackPort)))
+	assert.NoError(t, bc.Start())
+	defer bc.Stop()
+
+	f := New(0, nil, WithACKPort(ackPort), WithInterface(lo.Name))
+	assert.NoError(t, f.SendACK(net.IPv4(127, 0, 0, 1), 42))
+
+	assert.Eventually(t, func() bool {
+		return !bc.Broadcast().IsPairing()
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, uint64(42), bc.Broadcast().AppId())
+
+	f = New(0, nil, WithInterface("nonexistent0"))
+	assert.Error(t, f.SendACK(net.IPv4(127, 0, 0, 1), 42))
+}
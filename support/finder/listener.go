@@ -10,13 +10,25 @@ import (
 	"golang.org/x/sys/unix"
 )
 
-func listener(addr string) (*net.UDPConn, error) {
+// listener returns an UDP connection listening on the given address. If iface
+// is non-nil, the connection is bound to it.
+func listener(addr string, iface *net.Interface) (*net.UDPConn, error) {
 	lc := net.ListenConfig{
 		Control: func(network, address string, c syscall.RawConn) error {
-			return c.Control(func(fd uintptr) {
+			var err error
+			cErr := c.Control(func(fd uintptr) {
 				unix.SetsockoptInt(int(fd), unix.SOL_SOCKET,
 					unix.SO_REUSEADDR, 1)
+
+				if iface != nil {
+					err = bindToInterface(int(fd), iface)
+				}
 			})
+			if cErr != nil {
+				return cErr
+			}
+
+			return err
 		},
 	}
 
@@ -27,3 +39,27 @@ func listener(addr string) (*net.UDPConn, error) {
 
 	return conn.(*net.UDPConn), nil
 }
+
+// dialer returns a Dialer for connections from the given local address. If
+// iface is non-nil, connections are bound to it.
+func dialer(localAddr *net.UDPAddr, iface *net.Interface) *net.Dialer {
+	d := &net.Dialer{
+		LocalAddr: localAddr,
+	}
+
+	if iface != nil {
+		d.Control = func(network, address string, c syscall.RawConn) error {
+			var err error
+			cErr := c.Control(func(fd uintptr) {
+				err = bindToInterface(int(fd), iface)
+			})
+			if cErr != nil {
+				return cErr
+			}
+
+			return err
+		}
+	}
+
+	return d
+}
@@ -8,7 +8,24 @@ import (
 	"golang.org/x/sys/windows"
 )
 
-func listener(addr string) (*net.UDPConn, error) {
+// listener returns an UDP connection listening on the given address. If iface
+// is non-nil, the connection is bound to its IPv4 address (Windows still
+// delivers broadcasts received on the interface to it).
+func listener(addr string, iface *net.Interface) (*net.UDPConn, error) {
+	if iface != nil {
+		_, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ip, err := interfaceIPv4(iface)
+		if err != nil {
+			return nil, err
+		}
+
+		addr = net.JoinHostPort(ip.String(), port)
+	}
+
 	lc := net.ListenConfig{
 		Control: func(network, address string, c syscall.RawConn) error {
 			return c.Control(func(fd uintptr) {
@@ -25,3 +42,12 @@ func listener(addr string) (*net.UDPConn, error) {
 
 	return conn.(*net.UDPConn), nil
 }
+
+// dialer returns a Dialer for connections from the given local address. As
+// the local address is in the given interface, connections are already bound
+// to it.
+func dialer(localAddr *net.UDPAddr, iface *net.Interface) *net.Dialer {
+	return &net.Dialer{
+		LocalAddr: localAddr,
+	}
+}
@@ -0,0 +1,83 @@
+package finder
+
+import (
+	"fmt"
+	"time"
+)
+
+// PresenceEventType is the type of a presence event.
+type PresenceEventType int
+
+const (
+	PresenceEventTypeAppeared PresenceEventType = iota // Robot was seen for the first time.
+	PresenceEventTypeUpdated                           // Robot broadcast data changed.
+	PresenceEventTypeLost                              // Robot was not seen for a while.
+)
+
+// String returns the string representation of the PresenceEventType.
+func (t PresenceEventType) String() string {
+	switch t {
+	case PresenceEventTypeAppeared:
+		return "Appeared"
+	case PresenceEventTypeUpdated:
+		return "Updated"
+	case PresenceEventTypeLost:
+		return "Lost"
+	default:
+		return "Unknown"
+	}
+}
+
+// Robot represents a robot currently seen in the network.
+type Robot struct {
+	broadcast *Broadcast
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+// Broadcast returns the last broadcast received from this robot.
+func (r *Robot) Broadcast() *Broadcast {
+	return r.broadcast
+}
+
+// FirstSeen returns the time this robot was first seen.
+func (r *Robot) FirstSeen() time.Time {
+	return r.firstSeen
+}
+
+// LastSeen returns the last time a broadcast from this robot was received.
+func (r *Robot) LastSeen() time.Time {
+	return r.lastSeen
+}
+
+// String returns a string representation of this robot.
+func (r *Robot) String() string {
+	return fmt.Sprintf("%s, FirstSeen:%s, LastSeen:%s", r.broadcast,
+		r.firstSeen.Format(time.RFC3339), r.lastSeen.Format(time.RFC3339))
+}
+
+// PresenceEvent represents a change in the presence of a robot in the network.
+type PresenceEvent struct {
+	typ   PresenceEventType
+	robot Robot
+}
+
+// Type returns the type of this event.
+func (e *PresenceEvent) Type() PresenceEventType {
+	return e.typ
+}
+
+// Robot returns a snapshot of the robot this event refers to at the time the
+// event was generated.
+func (e *PresenceEvent) Robot() *Robot {
+	return &e.robot
+}
+
+// String returns a string representation of this event.
+func (e *PresenceEvent) String() string {
+	return fmt.Sprintf("PresenceEvent: %s/%s", e.typ, &e.robot)
+}
+
+// PresenceCallback is the type of the callback function that will be called
+// when a presence event is generated.
+type PresenceCallback func(ev *PresenceEvent)
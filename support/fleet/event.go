@@ -0,0 +1,54 @@
+package fleet
+
+import "fmt"
+
+// EventType is the type of a fleet event.
+type EventType int
+
+const (
+	EventTypeJoined       EventType = iota // Robot was added to the fleet.
+	EventTypeLeft                          // Robot was removed or lost.
+	EventTypeDisconnected                  // Robot lost its connection.
+	EventTypeConnected                     // Robot connected.
+)
+
+// String returns the string representation of the EventType.
+func (t EventType) String() string {
+	switch t {
+	case EventTypeJoined:
+		return "Joined"
+	case EventTypeLeft:
+		return "Left"
+	case EventTypeDisconnected:
+		return "Disconnected"
+	case EventTypeConnected:
+		return "Connected"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event represents a change in the state of a robot in the fleet.
+type Event struct {
+	typ   EventType
+	robot *Robot
+}
+
+// Type returns the type of this event.
+func (e *Event) Type() EventType {
+	return e.typ
+}
+
+// Robot returns the robot this event refers to.
+func (e *Event) Robot() *Robot {
+	return e.robot
+}
+
+// String returns a string representation of this event.
+func (e *Event) String() string {
+	return fmt.Sprintf("Event: %s/%s", e.typ, e.robot)
+}
+
+// EventCallback is the type of the callback function that will be called when
+// a fleet event is generated.
+type EventCallback func(ev *Event)
@@ -0,0 +1,390 @@
+package fleet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+)
+
+// BridgeFactory is the prototype for functions that create a new UnityBridge
+// instance for the robot that sent the given broadcast. Note that the native
+// Unity Bridge library is a per-process singleton so, for real robots, the
+// returned bridge will usually be a proxy to a bridge running in a different
+// process.
+type BridgeFactory func(b *finder.Broadcast) (unitybridge.UnityBridge, error)
+
+// Fleet tracks all robots seen in the network and connects each one of them
+// to its own UnityBridge instance. Robots are identified by their MAC address
+// and app ID. It is thread safe.
+type Fleet struct {
+	appID      uint64
+	factory    BridgeFactory
+	finderOpts []finder.Option
+	l          *logger.Logger
+	d          *support.Dispatcher[*Event]
+
+	m      sync.Mutex
+	f      *finder.Finder
+	pt     token.Token
+	quit   chan struct{}
+	robots map[string]*Robot
+}
+
+// New returns a new Fleet instance. If appID is zero, robots with any app ID
+// will be added to the fleet. If it is non-zero, only robots with the given
+// app ID will be added. The given finder options are used to configure robot
+// discovery.
+func New(appID uint64, factory BridgeFactory, l *logger.Logger,
+	opts ...finder.Option) *Fleet {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Fleet{
+		appID:      appID,
+		factory:    factory,
+		finderOpts: opts,
+		l:          l.WithGroup("fleet"),
+		d:          support.NewDispatcher[*Event](),
+		robots:     make(map[string]*Robot),
+	}
+}
+
+// Start starts looking for robots in the network. Any robots found are
+// connected and added to the fleet. It returns a non-nil error if the fleet
+// is already started.
+func (f *Fleet) Start() error {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	if f.quit != nil {
+		return fmt.Errorf("fleet already started")
+	}
+
+	ch := make(chan *finder.Broadcast)
+
+	f.f = finder.New(f.appID, f.l, f.finderOpts...)
+
+	pt, err := f.f.AddPresenceListener(f.onPresenceEvent)
+	if err != nil {
+		f.f = nil
+		return err
+	}
+
+	err = f.f.StartFinding(ch)
+	if err != nil {
+		f.f.RemovePresenceListener(pt)
+		f.f = nil
+		return err
+	}
+
+	f.pt = pt
+	f.quit = make(chan struct{})
+
+	go f.loop(ch, f.quit)
+
+	return nil
+}
+
+// Stop stops looking for robots in the network and removes all robots from
+// the fleet. It returns a non-nil error if the fleet is not started.
+func (f *Fleet) Stop() error {
+	f.m.Lock()
+
+	if f.quit == nil {
+		f.m.Unlock()
+		return fmt.Errorf("fleet not started")
+	}
+
+	close(f.quit)
+	f.quit = nil
+
+	// Stop listening for presence events first so Lost events generated when
+	// finding stops do not race with the removals below.
+	err := errors.Join(f.f.RemovePresenceListener(f.pt), f.f.StopFinding())
+	f.f = nil
+	f.pt = 0
+
+	robots := make([]*Robot, 0, len(f.robots))
+	for _, r := range f.robots {
+		robots = append(robots, r)
+	}
+
+	f.m.Unlock()
+
+	for _, r := range robots {
+		// Robots already removed concurrently are fine.
+		_, removeErr := f.remove(r)
+		err = errors.Join(err, removeErr)
+	}
+
+	return err
+}
+
+// Robots returns a snapshot of all robots currently in the fleet.
+func (f *Fleet) Robots() []*Robot {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	robots := make([]*Robot, 0, len(f.robots))
+	for _, r := range f.robots {
+		robots = append(robots, r)
+	}
+
+	return robots
+}
+
+// Robot returns the robot with the given MAC address and app ID and true if
+// it is part of the fleet. Returns nil and false otherwise.
+func (f *Fleet) Robot(mac net.HardwareAddr, appID uint64) (*Robot, bool) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	r, ok := f.robots[robotID(mac, appID)]
+
+	return r, ok
+}
+
+// WaitForRobot waits until any robot in the fleet is connected and returns
+// it. It returns an error if no robot is connected within the given timeout.
+func (f *Fleet) WaitForRobot(timeout time.Duration) (*Robot, error) {
+	ch := make(chan *Robot, 1)
+
+	t, err := f.AddEventListener(func(ev *Event) {
+		if ev.Type() != EventTypeConnected {
+			return
+		}
+
+		select {
+		case ch <- ev.Robot():
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.RemoveEventListener(t)
+
+	// Robots might have connected before the listener was added.
+	for _, r := range f.Robots() {
+		if r.Connected() {
+			return r, nil
+		}
+	}
+
+	select {
+	case r := <-ch:
+		return r, nil
+	case <-time.After(timeout):
+		return nil, fmt.Errorf("no robot connected after %s", timeout)
+	}
+}
+
+// Remove disconnects the given robot and removes it from the fleet.
+func (f *Fleet) Remove(r *Robot) error {
+	ok, err := f.remove(r)
+	if !ok {
+		return fmt.Errorf("robot %s is not part of the fleet", r)
+	}
+
+	return err
+}
+
+// AddEventListener adds a listener for fleet events. Events are delivered in
+// the order they were generated. Returns a token that can be used to remove
+// the listener later.
+func (f *Fleet) AddEventListener(c EventCallback) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	return f.d.AddListener(c)
+}
+
+// RemoveEventListener removes the listener associated with the given token.
+func (f *Fleet) RemoveEventListener(t token.Token) error {
+	if t == 0 {
+		return fmt.Errorf("token cannot be 0")
+	}
+
+	return f.d.RemoveListener(t)
+}
+
+// ForEach calls the given function for all robots in the fleet concurrently
+// and waits for all of them to return. The returned error joins all errors
+// returned by the individual calls.
+func (f *Fleet) ForEach(fn func(r *Robot) error) error {
+	robots := f.Robots()
+
+	errs := make([]error, len(robots))
+
+	var wg sync.WaitGroup
+	wg.Add(len(robots))
+	for i, r := range robots {
+		go func(i int, r *Robot) {
+			defer wg.Done()
+			if err := fn(r); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", r, err)
+			}
+		}(i, r)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// SetKeyValueAll sets the given key to the given value on all robots in the
+// fleet (for example, to set the LED color on all robots).
+func (f *Fleet) SetKeyValueAll(k *key.Key, value any) error {
+	return f.ForEach(func(r *Robot) error {
+		return r.Bridge().SetKeyValueSync(k, value)
+	})
+}
+
+// PerformActionForKeyAll performs the action associated with the given key
+// on all robots in the fleet (for example, to stop all chassis).
+func (f *Fleet) PerformActionForKeyAll(k *key.Key, value any) error {
+	return f.ForEach(func(r *Robot) error {
+		return r.Bridge().PerformActionForKeySync(k, value)
+	})
+}
+
+// DirectSendKeyValueAll sends the given value for the given key to all robots
+// in the fleet.
+func (f *Fleet) DirectSendKeyValueAll(k *key.Key, value uint64) error {
+	return f.ForEach(func(r *Robot) error {
+		return r.Bridge().DirectSendKeyValue(k, value)
+	})
+}
+
+// remove disconnects the given robot and removes it from the fleet. It
+// returns false if the robot is not part of the fleet.
+func (f *Fleet) remove(r *Robot) (bool, error) {
+	f.m.Lock()
+
+	if _, ok := f.robots[r.ID()]; !ok {
+		f.m.Unlock()
+		return false, nil
+	}
+
+	delete(f.robots, r.ID())
+
+	f.m.Unlock()
+
+	err := r.disconnect()
+
+	f.notifyListeners(EventTypeLeft, r)
+
+	return true, err
+}
+
+func (f *Fleet) loop(ch <-chan *finder.Broadcast, quit <-chan struct{}) {
+	for {
+		select {
+		case b := <-ch:
+			f.handleBroadcast(b)
+		case <-quit:
+			return
+		}
+	}
+}
+
+func (f *Fleet) handleBroadcast(b *finder.Broadcast) {
+	if b.IsPairing() {
+		// Robots in pairing mode can not be connected to.
+		f.l.Debug("Ignoring robot in pairing mode", "broadcast", b)
+		return
+	}
+
+	id := robotID(b.SourceMac(), b.AppId())
+
+	f.m.Lock()
+	r, ok := f.robots[id]
+	f.m.Unlock()
+
+	if ok {
+		if r.setIP(b.SourceIp()) {
+			f.l.Debug("Robot IP changed", "robot", r)
+			r.resetConnection()
+		}
+
+		return
+	}
+
+	ub, err := f.factory(b)
+	if err != nil {
+		f.l.Error("Error creating bridge for robot", "broadcast", b, "err", err)
+		return
+	}
+
+	r = newRobot(b.SourceMac(), b.AppId(), b.SourceIp(), ub)
+
+	err = r.connect(f.onConnectionChanged)
+	if err != nil {
+		f.l.Error("Error connecting to robot", "robot", r, "err", err)
+		return
+	}
+
+	f.m.Lock()
+	f.robots[id] = r
+	f.m.Unlock()
+
+	f.l.Debug("Robot joined", "robot", r)
+
+	f.notifyListeners(EventTypeJoined, r)
+}
+
+func (f *Fleet) onPresenceEvent(ev *finder.PresenceEvent) {
+	b := ev.Robot().Broadcast()
+
+	switch ev.Type() {
+	case finder.PresenceEventTypeLost:
+		if r, ok := f.Robot(b.SourceMac(), b.AppId()); ok {
+			f.l.Debug("Robot lost", "robot", r)
+			f.removeLost(r)
+		}
+	case finder.PresenceEventTypeUpdated:
+		// A robot that changed its app ID is a new fleet member (added when
+		// its broadcast is handled), so the entry for the old one is stale.
+		for _, r := range f.Robots() {
+			if bytes.Equal(r.MAC(), b.SourceMac()) && r.AppID() != b.AppId() {
+				f.l.Debug("Robot app ID changed", "robot", r,
+					"appID", b.AppId())
+				f.removeLost(r)
+			}
+		}
+	}
+}
+
+func (f *Fleet) removeLost(r *Robot) {
+	// Robots might have been removed concurrently, which is fine.
+	if _, err := f.remove(r); err != nil {
+		f.l.Error("Error removing lost robot", "robot", r, "err", err)
+	}
+}
+
+func (f *Fleet) onConnectionChanged(r *Robot, connected bool) {
+	if connected {
+		f.notifyListeners(EventTypeConnected, r)
+	} else {
+		f.notifyListeners(EventTypeDisconnected, r)
+	}
+}
+
+func (f *Fleet) notifyListeners(typ EventType, r *Robot) {
+	f.d.Dispatch(&Event{
+		typ:   typ,
+		robot: r,
+	})
+}
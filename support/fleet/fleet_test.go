@@ -0,0 +1,300 @@
+package fleet
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/unity/event"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+// testFleet is a Fleet that finds robots broadcasting to a test port and
+// connects them to fake bridges.
+type testFleet struct {
+	*Fleet
+
+	port int
+
+	m       sync.Mutex
+	bridges map[string]*fakebridge.Bridge
+}
+
+func newTestFleet(t *testing.T, appID uint64) *testFleet {
+	tf := &testFleet{
+		port:    nettest.FreeUDPPort(t),
+		bridges: make(map[string]*fakebridge.Bridge),
+	}
+
+	tf.Fleet = New(appID, func(b *finder.Broadcast) (
+		unitybridge.UnityBridge, error) {
+		ub := fakebridge.New()
+
+		tf.m.Lock()
+		tf.bridges[b.SourceMac().String()] = ub
+		tf.m.Unlock()
+
+		return ub, nil
+	}, nil, finder.WithPort(tf.port),
+		finder.WithExpiry(200*time.Millisecond))
+
+	return tf
+}
+
+func (tf *testFleet) bridge(mac net.HardwareAddr) *fakebridge.Bridge {
+	tf.m.Lock()
+	defer tf.m.Unlock()
+
+	return tf.bridges[mac.String()]
+}
+
+// startBeacon starts a loopback beacon for a robot with the given MAC
+// address.
+func (tf *testFleet) startBeacon(t *testing.T, mac net.HardwareAddr,
+	isPairing bool, appID uint64) *finder.Beacon {
+	b, err := finder.NewBroadcast(isPairing, net.IPv4(127, 0, 0, 1), mac,
+		appID)
+	assert.NoError(t, err)
+
+	bc := finder.NewBeacon(b, nil,
+		finder.WithBeaconTarget(fmt.Sprintf("127.0.0.1:%d", tf.port)),
+		finder.WithBeaconACKAddress(fmt.Sprintf(":%d",
+			nettest.FreeUDPPort(t))),
+		finder.WithBeaconInterval(10*time.Millisecond))
+	assert.NoError(t, bc.Start())
+
+	return bc
+}
+
+func (tf *testFleet) events(t *testing.T) chan *Event {
+	ch := make(chan *Event, 10)
+
+	_, err := tf.AddEventListener(func(ev *Event) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	return ch
+}
+
+func nextEvent(t *testing.T, ch chan *Event) *Event {
+	t.Helper()
+
+	select {
+	case ev := <-ch:
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no fleet event")
+	}
+
+	return nil
+}
+
+func TestFleet_JoinAndLeave(t *testing.T) {
+	tf := newTestFleet(t, 1)
+	ch := tf.events(t)
+
+	assert.NoError(t, tf.Start())
+	assert.Error(t, tf.Start())
+	defer tf.Stop()
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	bc := tf.startBeacon(t, mac, false, 1)
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeJoined, ev.Type())
+	assert.Equal(t, mac, ev.Robot().MAC())
+	assert.Equal(t, uint64(1), ev.Robot().AppID())
+	assert.Equal(t, "127.0.0.1", ev.Robot().IP().String())
+
+	r, ok := tf.Robot(mac, 1)
+	assert.True(t, ok)
+	assert.Equal(t, ev.Robot(), r)
+	assert.Len(t, tf.Robots(), 1)
+
+	// The bridge was started and told to connect to the robot.
+	ub := tf.bridge(mac)
+	assert.True(t, ub.Started())
+	assert.Contains(t, ub.Events(), fakebridge.SentEvent{
+		Event: event.NewFromTypeAndSubType(event.TypeConnection, 2),
+		Data:  "127.0.0.1",
+	})
+
+	// Robot is lost once it stops broadcasting.
+	assert.NoError(t, bc.Stop())
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeLeft, ev.Type())
+	assert.Equal(t, r, ev.Robot())
+	assert.Empty(t, tf.Robots())
+	assert.False(t, ub.Started())
+	assert.Equal(t, 0, ub.Listeners(key.KeyAirLinkConnection))
+}
+
+func TestFleet_IgnoresOtherRobots(t *testing.T) {
+	tf := newTestFleet(t, 1)
+	ch := tf.events(t)
+
+	assert.NoError(t, tf.Start())
+	defer tf.Stop()
+
+	mac1, _ := net.ParseMAC("00:11:22:33:44:55")
+	mac2, _ := net.ParseMAC("00:11:22:33:44:66")
+	mac3, _ := net.ParseMAC("00:11:22:33:44:77")
+
+	// Robots in pairing mode or with other app IDs are ignored.
+	defer tf.startBeacon(t, mac1, true, 1).Stop()
+	defer tf.startBeacon(t, mac2, false, 2).Stop()
+	defer tf.startBeacon(t, mac3, false, 1).Stop()
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeJoined, ev.Type())
+	assert.Equal(t, mac3, ev.Robot().MAC())
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, tf.Robots(), 1)
+}
+
+func TestFleet_WaitForRobot(t *testing.T) {
+	tf := newTestFleet(t, 0)
+	ch := tf.events(t)
+
+	_, err := tf.WaitForRobot(50 * time.Millisecond)
+	assert.Error(t, err)
+
+	assert.NoError(t, tf.Start())
+	defer tf.Stop()
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	defer tf.startBeacon(t, mac, false, 1).Stop()
+
+	r := nextEvent(t, ch).Robot()
+	assert.False(t, r.Connected())
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		tf.bridge(mac).Send(key.KeyAirLinkConnection, &value.Bool{Value: true})
+	}()
+
+	connected, err := tf.WaitForRobot(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, r, connected)
+	assert.True(t, r.Connected())
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeConnected, ev.Type())
+	assert.Equal(t, r, ev.Robot())
+
+	// Robots that are already connected are returned immediately.
+	connected, err = tf.WaitForRobot(0)
+	assert.NoError(t, err)
+	assert.Equal(t, r, connected)
+
+	tf.bridge(mac).Send(key.KeyAirLinkConnection, &value.Bool{Value: false})
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeDisconnected, ev.Type())
+	assert.Equal(t, r, ev.Robot())
+}
+
+func TestFleet_AppIDChange(t *testing.T) {
+	tf := newTestFleet(t, 0)
+	ch := tf.events(t)
+
+	assert.NoError(t, tf.Start())
+	defer tf.Stop()
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	bc := tf.startBeacon(t, mac, false, 1)
+	r1 := nextEvent(t, ch).Robot()
+	assert.NoError(t, bc.Stop())
+
+	// The robot starts broadcasting a different app ID before it expires.
+	defer tf.startBeacon(t, mac, false, 2).Stop()
+
+	var joined, left *Robot
+	for joined == nil || left == nil {
+		ev := nextEvent(t, ch)
+		switch ev.Type() {
+		case EventTypeJoined:
+			joined = ev.Robot()
+		case EventTypeLeft:
+			left = ev.Robot()
+		}
+	}
+
+	assert.Equal(t, uint64(2), joined.AppID())
+	assert.Equal(t, r1, left)
+	assert.Equal(t, []*Robot{joined}, tf.Robots())
+}
+
+func TestFleet_StartError(t *testing.T) {
+	tf := newTestFleet(t, 0)
+
+	// Finding fails if the port is already in use.
+	conn, err := net.ListenPacket("udp4", fmt.Sprintf(":%d", tf.port))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	assert.Error(t, tf.Start())
+	assert.Error(t, tf.Stop())
+}
+
+func TestFleet_RemoveAndStop(t *testing.T) {
+	tf := newTestFleet(t, 0)
+	ch := tf.events(t)
+
+	assert.Error(t, tf.Stop())
+	assert.NoError(t, tf.Start())
+
+	mac1, _ := net.ParseMAC("00:11:22:33:44:55")
+	mac2, _ := net.ParseMAC("00:11:22:33:44:66")
+
+	defer tf.startBeacon(t, mac1, false, 1).Stop()
+
+	r1 := nextEvent(t, ch).Robot()
+
+	defer tf.startBeacon(t, mac2, false, 1).Stop()
+
+	r2 := nextEvent(t, ch).Robot()
+
+	// Actions and values are sent to all robots.
+	assert.NoError(t, tf.SetKeyValueAll(key.KeyRobomasterSystemLEDColor,
+		&value.LEDColor{R: 255}))
+	assert.NoError(t, tf.PerformActionForKeyAll(key.KeyRobomasterSystemKill,
+		nil))
+
+	for _, mac := range []net.HardwareAddr{mac1, mac2} {
+		ub := tf.bridge(mac)
+		assert.Equal(t, &value.LEDColor{R: 255},
+			ub.Value(key.KeyRobomasterSystemLEDColor))
+		assert.Equal(t, []*key.Key{key.KeyRobomasterSystemKill},
+			ub.ActionKeys())
+	}
+
+	assert.NoError(t, tf.Remove(r1))
+	assert.Error(t, tf.Remove(r1))
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeLeft, ev.Type())
+	assert.Equal(t, r1, ev.Robot())
+
+	// Stopping removes all robots.
+	assert.NoError(t, tf.Stop())
+	assert.Error(t, tf.Stop())
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeLeft, ev.Type())
+	assert.Equal(t, r2, ev.Robot())
+	assert.Empty(t, tf.Robots())
+}
@@ -0,0 +1,178 @@
+package fleet
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/event"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+const robotPort = 10607
+
+// Robot is a handle to a single robot in the fleet. Each robot is connected
+// through its own UnityBridge instance.
+type Robot struct {
+	mac   net.HardwareAddr
+	appID uint64
+	ub    unitybridge.UnityBridge
+
+	m         sync.Mutex
+	ip        net.IP
+	connected bool
+	t         token.Token
+}
+
+func newRobot(mac net.HardwareAddr, appID uint64, ip net.IP,
+	ub unitybridge.UnityBridge) *Robot {
+	return &Robot{
+		mac:   mac,
+		appID: appID,
+		ub:    ub,
+		ip:    ip,
+	}
+}
+
+// ID returns an unique identifier for this robot built from its MAC address
+// and app ID.
+func (r *Robot) ID() string {
+	return robotID(r.mac, r.appID)
+}
+
+// MAC returns the hardware address of this robot.
+func (r *Robot) MAC() net.HardwareAddr {
+	return r.mac
+}
+
+// AppID returns the app ID this robot is paired with.
+func (r *Robot) AppID() uint64 {
+	return r.appID
+}
+
+// IP returns the last known IP address of this robot.
+func (r *Robot) IP() net.IP {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.ip
+}
+
+// Connected returns true if the robot is currently connected.
+func (r *Robot) Connected() bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return r.connected
+}
+
+// Bridge returns the UnityBridge instance associated with this robot. It can
+// be used to directly control the robot.
+func (r *Robot) Bridge() unitybridge.UnityBridge {
+	return r.ub
+}
+
+// String returns a string representation of this robot.
+func (r *Robot) String() string {
+	return fmt.Sprintf("Robot{MAC: %s, AppID: %d, IP: %s}", r.mac, r.appID,
+		r.IP())
+}
+
+// connect starts the robot UnityBridge, starts monitoring its connection
+// status and connects it to the robot IP. Connection status changes are
+// reported to the given callback.
+func (r *Robot) connect(cb func(r *Robot, connected bool)) error {
+	err := r.ub.Start()
+	if err != nil {
+		return err
+	}
+
+	t, err := r.ub.AddKeyListener(key.KeyAirLinkConnection,
+		func(res *result.Result) {
+			if !res.Succeeded() {
+				return
+			}
+
+			connected := res.Value().(*value.Bool).Value
+
+			r.m.Lock()
+			changed := r.connected != connected
+			r.connected = connected
+			r.m.Unlock()
+
+			if changed {
+				cb(r, connected)
+			}
+		}, false)
+	if err != nil {
+		r.ub.Stop()
+		return err
+	}
+
+	r.m.Lock()
+	r.t = t
+	r.m.Unlock()
+
+	r.resetConnection()
+
+	return nil
+}
+
+// disconnect closes the connection to the robot and stops its UnityBridge.
+func (r *Robot) disconnect() error {
+	r.m.Lock()
+	t := r.t
+	r.t = 0
+	r.connected = false
+	r.m.Unlock()
+
+	if t != 0 {
+		r.ub.RemoveKeyListener(key.KeyAirLinkConnection, t)
+	}
+
+	r.closeConnection()
+
+	return r.ub.Stop()
+}
+
+// setIP updates the robot IP address. It returns true if the IP changed.
+func (r *Robot) setIP(ip net.IP) bool {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	if r.ip.Equal(ip) {
+		return false
+	}
+
+	r.ip = ip
+
+	return true
+}
+
+// resetConnection should be called whenever the IP for the robot changes. It
+// is safe to call it whenever a connection needs to be stablished anyway.
+func (r *Robot) resetConnection() {
+	r.closeConnection()
+
+	ev := event.NewFromTypeAndSubType(event.TypeConnection, 2)
+	r.ub.SendEventWithString(ev, r.IP().String())
+
+	ev.ResetSubType(3)
+	r.ub.SendEventWithUint64(ev, robotPort)
+
+	ev.ResetSubType(0)
+	r.ub.SendEvent(ev)
+}
+
+func (r *Robot) closeConnection() {
+	ev := event.NewFromTypeAndSubType(event.TypeConnection, 1)
+	r.ub.SendEvent(ev)
+}
+
+func robotID(mac net.HardwareAddr, appID uint64) string {
+	return fmt.Sprintf("%s/%d", mac, appID)
+}
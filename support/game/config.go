@@ -0,0 +1,43 @@
+package game
+
+import "fmt"
+
+// Color is the team color of a robot in a match
+// (KeyRobomasterSystemGameColorConfig).
+type Color uint64
+
+const (
+	ColorRed Color = iota
+	ColorBlue
+)
+
+// String returns the string representation of the Color.
+func (c Color) String() string {
+	switch c {
+	case ColorRed:
+		return "Red"
+	case ColorBlue:
+		return "Blue"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint64(c))
+}
+
+// Role is the role of a robot in a match
+// (KeyRobomasterSystemGameRoleConfig). Its meaning depends on the match
+// rules.
+type Role uint64
+
+// Status is a snapshot of the robot match status.
+type Status struct {
+	Running        bool
+	CurrentHP      uint64
+	TotalHP        uint64
+	CurrentBullets uint64
+	TotalBullets   uint64
+}
+
+// Alive returns true if the robot still has HP left.
+func (s Status) Alive() bool {
+	return s.CurrentHP > 0
+}
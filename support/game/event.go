@@ -0,0 +1,60 @@
+package game
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType is the type of a match event.
+type EventType uint8
+
+const (
+	// EventTypeStart is emitted when a match starts.
+	EventTypeStart EventType = iota
+
+	// EventTypeEnd is emitted when a match ends.
+	EventTypeEnd
+
+	// EventTypeHit is emitted when the robot loses HP without being killed.
+	EventTypeHit
+
+	// EventTypeKill is emitted when the robot HP drops to zero. Kills are
+	// detected from HP updates only, so the event for Game.Kill() is emitted
+	// when the robot reports the HP change and not when the action succeeds.
+	EventTypeKill
+
+	// EventTypeRevive is emitted when a killed robot gets HP back. Like kills,
+	// revives (including the ones requested with Game.Revive()) are detected
+	// from HP updates only.
+	EventTypeRevive
+)
+
+// String returns the string representation of the EventType.
+func (t EventType) String() string {
+	switch t {
+	case EventTypeStart:
+		return "Start"
+	case EventTypeEnd:
+		return "End"
+	case EventTypeHit:
+		return "Hit"
+	case EventTypeKill:
+		return "Kill"
+	case EventTypeRevive:
+		return "Revive"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(t))
+}
+
+// Event is a match event. PreviousHP and Status.CurrentHP are the robot HP
+// before and after the event.
+type Event struct {
+	Type       EventType
+	Time       time.Time
+	PreviousHP uint64
+	Status     Status
+}
+
+// EventCallback is called for every match event.
+type EventCallback func(Event)
@@ -0,0 +1,342 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// Game runs local battle matches on a robot. It configures the robot role and
+// team color, starts and ends matches and tracks the robot HP and ammo,
+// emitting typed events as the match progresses. It is thread safe.
+type Game struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+	d  *support.Dispatcher[Event]
+
+	m      sync.Mutex
+	tokens map[*key.Key]token.Token
+	status Status
+	hasHP  bool
+}
+
+// New returns a new Game instance for the robot connected to the given
+// UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *Game {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Game{
+		ub: ub,
+		l:  l.WithGroup("game"),
+		d:  support.NewDispatcher[Event](),
+	}
+}
+
+// Start starts tracking the robot match status.
+func (g *Game) Start() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.tokens != nil {
+		return fmt.Errorf("game already started")
+	}
+
+	g.status = Status{}
+	g.hasHP = false
+	g.tokens = make(map[*key.Key]token.Token)
+
+	callbacks := map[*key.Key]result.Callback{
+		key.KeyRobomasterSystemIsGameRunning: g.onRunning,
+		key.KeyRobomasterSystemCurrentHP:     g.onCurrentHP,
+		key.KeyRobomasterSystemTotalHP: g.onUint64(func(s *Status) *uint64 {
+			return &s.TotalHP
+		}),
+		key.KeyRobomasterSystemCurrentBullets: g.onUint64(func(s *Status) *uint64 {
+			return &s.CurrentBullets
+		}),
+		key.KeyRobomasterSystemTotalBullets: g.onUint64(func(s *Status) *uint64 {
+			return &s.TotalBullets
+		}),
+	}
+
+	for k, c := range callbacks {
+		// The current HP baseline is read below, so only its changes are
+		// needed.
+		t, err := g.ub.AddKeyListener(k, c,
+			k != key.KeyRobomasterSystemCurrentHP)
+		if err != nil {
+			return errors.Join(err, g.stopLocked())
+		}
+
+		g.tokens[k] = t
+	}
+
+	// Callbacks can not run until the mutex is released, so HP changes are
+	// always compared against this baseline. If it can not be read, the first
+	// HP update is used instead.
+	hp, err := g.readUint64(key.KeyRobomasterSystemCurrentHP)
+	if err != nil {
+		g.l.Warn("Error reading current HP", "error", err)
+		return nil
+	}
+
+	g.status.CurrentHP = hp
+	g.hasHP = true
+
+	return nil
+}
+
+// Stop stops tracking the robot match status.
+func (g *Game) Stop() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.tokens == nil {
+		return fmt.Errorf("game not started")
+	}
+
+	return g.stopLocked()
+}
+
+// Configure sets the robot role and team color for the next match.
+func (g *Game) Configure(role Role, color Color) error {
+	err := g.ub.SetKeyValueSync(key.KeyRobomasterSystemGameRoleConfig,
+		&value.Uint64{Value: uint64(role)})
+	if err != nil {
+		return err
+	}
+
+	return g.ub.SetKeyValueSync(key.KeyRobomasterSystemGameColorConfig,
+		&value.Uint64{Value: uint64(color)})
+}
+
+// Config returns the robot role and team color.
+func (g *Game) Config() (Role, Color, error) {
+	role, err := g.readUint64(key.KeyRobomasterSystemGameRoleConfig)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	color, err := g.readUint64(key.KeyRobomasterSystemGameColorConfig)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return Role(role), Color(color), nil
+}
+
+// StartMatch starts a match (KeyRobomasterSystemGameStart).
+func (g *Game) StartMatch() error {
+	return g.ub.PerformActionForKeySync(key.KeyRobomasterSystemGameStart, nil)
+}
+
+// EndMatch ends the running match (KeyRobomasterSystemGameEnd).
+func (g *Game) EndMatch() error {
+	return g.ub.PerformActionForKeySync(key.KeyRobomasterSystemGameEnd, nil)
+}
+
+// Kill kills the robot (KeyRobomasterSystemKill). The resulting kill event is
+// emitted when the robot reports its HP dropped to zero.
+func (g *Game) Kill() error {
+	return g.ub.PerformActionForKeySync(key.KeyRobomasterSystemKill, nil)
+}
+
+// Revive revives the robot (KeyRobomasterSystemRevive). The resulting revive
+// event is emitted when the robot reports its HP back.
+func (g *Game) Revive() error {
+	return g.ub.PerformActionForKeySync(key.KeyRobomasterSystemRevive, nil)
+}
+
+// SetHP sets the robot current and total HP.
+func (g *Game) SetHP(current, total uint64) error {
+	err := g.ub.SetKeyValueSync(key.KeyRobomasterSystemTotalHP,
+		&value.Uint64{Value: total})
+	if err != nil {
+		return err
+	}
+
+	return g.ub.SetKeyValueSync(key.KeyRobomasterSystemCurrentHP,
+		&value.Uint64{Value: current})
+}
+
+// SetBullets sets the robot current and total bullets.
+func (g *Game) SetBullets(current, total uint64) error {
+	err := g.ub.SetKeyValueSync(key.KeyRobomasterSystemTotalBullets,
+		&value.Uint64{Value: total})
+	if err != nil {
+		return err
+	}
+
+	return g.ub.SetKeyValueSync(key.KeyRobomasterSystemCurrentBullets,
+		&value.Uint64{Value: current})
+}
+
+// Status returns the last known robot match status.
+func (g *Game) Status() Status {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	return g.status
+}
+
+// Buffs returns the IDs of the buffs currently applied to the robot.
+func (g *Game) Buffs() ([]uint8, error) {
+	return g.readList(key.KeyRobomasterSystemBuffs)
+}
+
+// Equipments returns the IDs of the equipments currently available to the
+// robot.
+func (g *Game) Equipments() ([]uint8, error) {
+	return g.readList(key.KeyRobomasterSystemEquipments)
+}
+
+// AddListener adds a callback to be called for every match event. Events are
+// delivered in the order they happened. It returns a token that can be used
+// to remove it later.
+func (g *Game) AddListener(c EventCallback) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	return g.d.AddListener(c)
+}
+
+// RemoveListener removes the callback associated with the given token.
+func (g *Game) RemoveListener(t token.Token) error {
+	return g.d.RemoveListener(t)
+}
+
+func (g *Game) onRunning(r *result.Result) {
+	if !r.Succeeded() {
+		g.l.Error("Error reading game running state", "result", r)
+		return
+	}
+
+	running := r.Value().(*value.Bool).Value
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if running == g.status.Running {
+		return
+	}
+
+	g.status.Running = running
+
+	eventType := EventTypeEnd
+	if running {
+		eventType = EventTypeStart
+	}
+
+	g.notifyListenersLocked(eventType, g.status.CurrentHP)
+}
+
+func (g *Game) onCurrentHP(r *result.Result) {
+	if !r.Succeeded() {
+		g.l.Error("Error reading current HP", "result", r)
+		return
+	}
+
+	hp := r.Value().(*value.Uint64).Value
+
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	previous := g.status.CurrentHP
+	hadHP := g.hasHP
+
+	g.status.CurrentHP = hp
+	g.hasHP = true
+
+	// The first value is only the baseline.
+	if !hadHP || hp == previous {
+		return
+	}
+
+	switch {
+	case hp == 0:
+		g.notifyListenersLocked(EventTypeKill, previous)
+	case previous == 0:
+		g.notifyListenersLocked(EventTypeRevive, previous)
+	case hp < previous:
+		g.notifyListenersLocked(EventTypeHit, previous)
+	}
+}
+
+// onUint64 returns a callback that stores uint64 results in the status
+// field returned by the given function.
+func (g *Game) onUint64(field func(*Status) *uint64) result.Callback {
+	return func(r *result.Result) {
+		if !r.Succeeded() {
+			g.l.Error("Error reading game status", "result", r)
+			return
+		}
+
+		g.m.Lock()
+		defer g.m.Unlock()
+
+		*field(&g.status) = r.Value().(*value.Uint64).Value
+	}
+}
+
+func (g *Game) notifyListenersLocked(t EventType, previousHP uint64) {
+	ev := Event{
+		Type:       t,
+		Time:       time.Now(),
+		PreviousHP: previousHP,
+		Status:     g.status,
+	}
+
+	g.l.Debug("Game event", "type", t, "status", g.status)
+
+	g.d.Dispatch(ev)
+}
+
+func (g *Game) stopLocked() error {
+	var err error
+	for k, t := range g.tokens {
+		err = errors.Join(err, g.ub.RemoveKeyListener(k, t))
+	}
+
+	g.tokens = nil
+
+	return err
+}
+
+func (g *Game) readUint64(k *key.Key) (uint64, error) {
+	r, err := g.ub.GetKeyValueSync(k, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.Succeeded() {
+		return 0, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	return r.Value().(*value.Uint64).Value, nil
+}
+
+func (g *Game) readList(k *key.Key) ([]uint8, error) {
+	r, err := g.ub.GetKeyValueSync(k, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.Succeeded() {
+		return nil, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	return r.Value().(*value.List[uint8]).List, nil
+}
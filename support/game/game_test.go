@@ -0,0 +1,216 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func sendHP(ub *fakebridge.Bridge, hp uint64) {
+	ub.Send(key.KeyRobomasterSystemCurrentHP, &value.Uint64{Value: hp})
+}
+
+func nextEvent(t *testing.T, ch chan Event) Event {
+	t.Helper()
+
+	select {
+	case ev := <-ch:
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no game event")
+	}
+
+	return Event{}
+}
+
+func TestStartStop(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+
+	keys := []*key.Key{
+		key.KeyRobomasterSystemIsGameRunning,
+		key.KeyRobomasterSystemCurrentHP,
+		key.KeyRobomasterSystemTotalHP,
+		key.KeyRobomasterSystemCurrentBullets,
+		key.KeyRobomasterSystemTotalBullets,
+	}
+
+	assert.NoError(t, g.Start())
+	assert.Error(t, g.Start())
+	for _, k := range keys {
+		assert.Equal(t, 1, ub.Listeners(k), k)
+	}
+
+	assert.NoError(t, g.Stop())
+	assert.Error(t, g.Stop())
+	for _, k := range keys {
+		assert.Equal(t, 0, ub.Listeners(k), k)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+
+	assert.NoError(t, g.Configure(Role(2), ColorBlue))
+
+	role, color, err := g.Config()
+	assert.NoError(t, err)
+	assert.Equal(t, Role(2), role)
+	assert.Equal(t, ColorBlue, color)
+	assert.Equal(t, "Blue", color.String())
+}
+
+func TestMatch(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+	assert.NoError(t, g.Start())
+	defer g.Stop()
+
+	ch := make(chan Event, 10)
+	tk, err := g.AddListener(func(ev Event) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, g.StartMatch())
+
+	ub.Send(key.KeyRobomasterSystemTotalHP, &value.Uint64{Value: 100})
+	ub.Send(key.KeyRobomasterSystemTotalBullets, &value.Uint64{Value: 50})
+	ub.Send(key.KeyRobomasterSystemCurrentBullets, &value.Uint64{Value: 40})
+	sendHP(ub, 100)
+	ub.Send(key.KeyRobomasterSystemIsGameRunning, &value.Bool{Value: true})
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeStart, ev.Type)
+	assert.Equal(t, Status{
+		Running:        true,
+		CurrentHP:      100,
+		TotalHP:        100,
+		CurrentBullets: 40,
+		TotalBullets:   50,
+	}, ev.Status)
+
+	sendHP(ub, 80)
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeHit, ev.Type)
+	assert.Equal(t, uint64(100), ev.PreviousHP)
+	assert.Equal(t, uint64(80), ev.Status.CurrentHP)
+
+	assert.NoError(t, g.Kill())
+	sendHP(ub, 0)
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeKill, ev.Type)
+	assert.False(t, g.Status().Alive())
+
+	assert.NoError(t, g.Revive())
+	sendHP(ub, 100)
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeRevive, ev.Type)
+	assert.True(t, g.Status().Alive())
+
+	assert.NoError(t, g.EndMatch())
+	ub.Send(key.KeyRobomasterSystemIsGameRunning, &value.Bool{Value: false})
+
+	ev = nextEvent(t, ch)
+	assert.Equal(t, EventTypeEnd, ev.Type)
+
+	assert.Equal(t, []*key.Key{
+		key.KeyRobomasterSystemGameStart,
+		key.KeyRobomasterSystemKill,
+		key.KeyRobomasterSystemRevive,
+		key.KeyRobomasterSystemGameEnd,
+	}, ub.ActionKeys())
+
+	assert.NoError(t, g.RemoveListener(tk))
+	assert.Error(t, g.RemoveListener(tk))
+}
+
+func TestMatch_HPBaseline(t *testing.T) {
+	ub := fakebridge.New()
+	ub.SetValue(key.KeyRobomasterSystemCurrentHP, &value.Uint64{Value: 100})
+
+	g := New(ub, nil)
+	assert.NoError(t, g.Start())
+	defer g.Stop()
+
+	assert.Equal(t, uint64(100), g.Status().CurrentHP)
+
+	ch := make(chan Event, 10)
+	_, err := g.AddListener(func(ev Event) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	// The first update after starting is already compared to the baseline.
+	sendHP(ub, 80)
+
+	ev := nextEvent(t, ch)
+	assert.Equal(t, EventTypeHit, ev.Type)
+	assert.Equal(t, uint64(100), ev.PreviousHP)
+	assert.Equal(t, uint64(80), ev.Status.CurrentHP)
+}
+
+func TestMatch_OrderedEvents(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+	assert.NoError(t, g.Start())
+	defer g.Stop()
+
+	ch := make(chan Event, 10)
+	_, err := g.AddListener(func(ev Event) {
+		ch <- ev
+	})
+	assert.NoError(t, err)
+
+	sendHP(ub, 100)
+
+	// Events are sent without waiting for the previous ones to be delivered.
+	ub.Send(key.KeyRobomasterSystemIsGameRunning, &value.Bool{Value: true})
+	sendHP(ub, 80)
+	sendHP(ub, 0)
+	sendHP(ub, 100)
+	ub.Send(key.KeyRobomasterSystemIsGameRunning, &value.Bool{Value: false})
+
+	for _, typ := range []EventType{EventTypeStart, EventTypeHit,
+		EventTypeKill, EventTypeRevive, EventTypeEnd} {
+		assert.Equal(t, typ, nextEvent(t, ch).Type)
+	}
+}
+
+func TestBuffsAndEquipments(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+
+	ub.SetValue(key.KeyRobomasterSystemBuffs, &value.List[uint8]{
+		List: []uint8{1, 3}})
+	ub.SetValue(key.KeyRobomasterSystemEquipments, &value.List[uint8]{})
+
+	buffs, err := g.Buffs()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint8{1, 3}, buffs)
+
+	equipments, err := g.Equipments()
+	assert.NoError(t, err)
+	assert.Empty(t, equipments)
+}
+
+func TestSetHPAndBullets(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+
+	assert.NoError(t, g.SetHP(50, 200))
+	assert.NoError(t, g.SetBullets(10, 100))
+
+	assert.Equal(t, &value.Uint64{Value: 50},
+		ub.Value(key.KeyRobomasterSystemCurrentHP))
+	assert.Equal(t, &value.Uint64{Value: 100},
+		ub.Value(key.KeyRobomasterSystemTotalBullets))
+}
@@ -0,0 +1,271 @@
+package gimbal
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// DefaultTolerance is the default maximum difference, in degrees, between the
+// gimbal attitude and the target of a move for the move to be considered
+// complete.
+const DefaultTolerance = 1.0
+
+// Gimbal controls a robot gimbal. Attitude updates are tracked while it is
+// started and moves block until the reported attitude converges to the
+// target. It is thread safe.
+type Gimbal struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+
+	m         sync.Mutex
+	rl        *support.ResultListener
+	tolerance float64
+}
+
+// New returns a new Gimbal instance that controls the gimbal of the robot
+// connected to the given UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *Gimbal {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &Gimbal{
+		ub:        ub,
+		l:         l.WithGroup("gimbal"),
+		tolerance: DefaultTolerance,
+	}
+}
+
+// Start starts tracking gimbal attitude updates. It must be called before
+// any other methods.
+func (g *Gimbal) Start() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.rl != nil {
+		return fmt.Errorf("gimbal already started")
+	}
+
+	rl := support.NewResultListener(g.ub, g.l, key.KeyGimbalAttitude, nil)
+	if err := rl.Start(); err != nil {
+		return err
+	}
+
+	g.rl = rl
+
+	return nil
+}
+
+// Stop stops tracking gimbal attitude updates.
+func (g *Gimbal) Stop() error {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.rl == nil {
+		return fmt.Errorf("gimbal not started")
+	}
+
+	err := g.rl.Stop()
+	g.rl = nil
+
+	return err
+}
+
+// SetTolerance sets the maximum difference, in degrees, between the gimbal
+// attitude and the target of a move for the move to be considered complete.
+func (g *Gimbal) SetTolerance(tolerance float64) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	g.tolerance = tolerance
+}
+
+// Attitude returns the current gimbal attitude. It waits up to the given
+// timeout for the first attitude update to arrive.
+func (g *Gimbal) Attitude(timeout time.Duration) (*value.GimbalAttitude,
+	error) {
+	rl, err := g.listener()
+	if err != nil {
+		return nil, err
+	}
+
+	r := rl.WaitForAnyResult(timeout)
+	if r == nil {
+		return nil, fmt.Errorf("timeout waiting for gimbal attitude")
+	}
+
+	if !r.Succeeded() {
+		return nil, fmt.Errorf("error reading gimbal attitude: %s",
+			r.ErrorDesc())
+	}
+
+	return r.Value().(*value.GimbalAttitude), nil
+}
+
+// MoveTo moves the gimbal to the given yaw and pitch angles (in degrees)
+// relative to the chassis front. It blocks until the gimbal reaches the
+// target angles or the timeout expires.
+func (g *Gimbal) MoveTo(yaw, pitch float32, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	err := g.ub.PerformActionForKeySync(key.KeyGimbalAngleFrontYawRotation,
+		&value.GimbalAngleRotation{Yaw: yaw})
+	if err != nil {
+		return err
+	}
+
+	err = g.ub.PerformActionForKeySync(key.KeyGimbalAngleFrontPitchRotation,
+		&value.GimbalAngleRotation{Pitch: pitch})
+	if err != nil {
+		return err
+	}
+
+	return g.waitForAttitude(yaw, pitch, deadline)
+}
+
+// Move moves the gimbal by the given yaw and pitch angles (in degrees)
+// relative to its current attitude. It blocks until the gimbal reaches the
+// target angles or the timeout expires.
+func (g *Gimbal) Move(yaw, pitch float32, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	attitude, err := g.Attitude(timeout)
+	if err != nil {
+		return err
+	}
+
+	err = g.ub.PerformActionForKeySync(key.KeyGimbalAngleIncrementRotation,
+		&value.GimbalAngleRotation{Yaw: yaw, Pitch: pitch})
+	if err != nil {
+		return err
+	}
+
+	return g.waitForAttitude(attitude.Yaw+yaw, attitude.Pitch+pitch, deadline)
+}
+
+// Recenter moves the gimbal back to its center position. It blocks until the
+// gimbal is centered or the timeout expires.
+func (g *Gimbal) Recenter(timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	err := g.ub.PerformActionForKeySync(key.KeyGimbalResetPosition,
+		&value.Uint64{})
+	if err != nil {
+		return err
+	}
+
+	return g.waitForAttitude(0, 0, deadline)
+}
+
+// SetSpeed starts rotating the gimbal at the given yaw and pitch speeds (in
+// degrees/s). Use zero speeds to stop it.
+func (g *Gimbal) SetSpeed(yaw, pitch float32) error {
+	err := g.ub.SetKeyValueSync(key.KeyGimbalSpeedRotationEnabled,
+		&value.Bool{Value: yaw != 0 || pitch != 0})
+	if err != nil {
+		return err
+	}
+
+	return g.ub.PerformActionForKeySync(key.KeyGimbalSpeedRotation,
+		&value.GimbalSpeedRotation{Yaw: yaw, Pitch: pitch})
+}
+
+// WorkMode returns the current gimbal work mode.
+func (g *Gimbal) WorkMode() (WorkMode, error) {
+	v, err := g.readUint64(key.KeyGimbalWorkMode)
+
+	return WorkMode(v), err
+}
+
+// SetWorkMode sets the gimbal work mode.
+func (g *Gimbal) SetWorkMode(m WorkMode) error {
+	return g.ub.SetKeyValueSync(key.KeyGimbalWorkMode,
+		&value.Uint64{Value: uint64(m)})
+}
+
+// ControlMode returns the current gimbal control mode.
+func (g *Gimbal) ControlMode() (ControlMode, error) {
+	v, err := g.readUint64(key.KeyGimbalControlMode)
+
+	return ControlMode(v), err
+}
+
+// SetControlMode sets the gimbal control mode.
+func (g *Gimbal) SetControlMode(m ControlMode) error {
+	return g.ub.SetKeyValueSync(key.KeyGimbalControlMode,
+		&value.Uint64{Value: uint64(m)})
+}
+
+func (g *Gimbal) listener() (*support.ResultListener, error) {
+	g.m.Lock()
+	defer g.m.Unlock()
+
+	if g.rl == nil {
+		return nil, fmt.Errorf("gimbal not started")
+	}
+
+	return g.rl, nil
+}
+
+// waitForAttitude waits until the gimbal attitude is within the configured
+// tolerance of the given yaw and pitch or the deadline is reached.
+func (g *Gimbal) waitForAttitude(yaw, pitch float32, deadline time.Time) error {
+	rl, err := g.listener()
+	if err != nil {
+		return err
+	}
+
+	g.m.Lock()
+	tolerance := g.tolerance
+	g.m.Unlock()
+
+	converged := func(r *result.Result) bool {
+		if r == nil || !r.Succeeded() {
+			return false
+		}
+
+		attitude := r.Value().(*value.GimbalAttitude)
+
+		return math.Abs(float64(attitude.Yaw-yaw)) <= tolerance &&
+			math.Abs(float64(attitude.Pitch-pitch)) <= tolerance
+	}
+
+	if converged(rl.Result()) {
+		return nil
+	}
+
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timeout waiting for gimbal to reach yaw %.1f "+
+				"and pitch %.1f", yaw, pitch)
+		}
+
+		if converged(rl.WaitForNewResult(remaining)) {
+			return nil
+		}
+	}
+}
+
+func (g *Gimbal) readUint64(k *key.Key) (uint64, error) {
+	r, err := g.ub.GetKeyValueSync(k, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.Succeeded() {
+		return 0, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	return r.Value().(*value.Uint64).Value, nil
+}
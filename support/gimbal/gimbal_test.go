@@ -0,0 +1,163 @@
+package gimbal
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+// simulator makes a fake bridge behave like a gimbal that instantly moves to
+// the requested angles.
+type simulator struct {
+	ub *fakebridge.Bridge
+
+	m        sync.Mutex
+	attitude value.GimbalAttitude
+	stuck    bool
+}
+
+func newSimulator() *simulator {
+	s := &simulator{
+		ub: fakebridge.New(),
+	}
+
+	s.ub.HandleActions(s.onAction)
+
+	return s
+}
+
+func (s *simulator) onAction(k *key.Key, v any) error {
+	s.m.Lock()
+
+	if !s.stuck {
+		switch k {
+		case key.KeyGimbalAngleFrontYawRotation:
+			s.attitude.Yaw = v.(*value.GimbalAngleRotation).Yaw
+		case key.KeyGimbalAngleFrontPitchRotation:
+			s.attitude.Pitch = v.(*value.GimbalAngleRotation).Pitch
+		case key.KeyGimbalAngleIncrementRotation:
+			s.attitude.Yaw += v.(*value.GimbalAngleRotation).Yaw
+			s.attitude.Pitch += v.(*value.GimbalAngleRotation).Pitch
+		case key.KeyGimbalResetPosition:
+			s.attitude = value.GimbalAttitude{}
+		}
+	}
+
+	s.m.Unlock()
+
+	s.sendAttitude()
+
+	return nil
+}
+
+func (s *simulator) sendAttitude() {
+	s.m.Lock()
+	attitude := s.attitude
+	s.m.Unlock()
+
+	s.ub.Send(key.KeyGimbalAttitude, &attitude)
+}
+
+func (s *simulator) setStuck(stuck bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.stuck = stuck
+}
+
+func setupGimbal(t *testing.T) (*simulator, *Gimbal) {
+	s := newSimulator()
+
+	g := New(s.ub, nil)
+	assert.NoError(t, g.Start())
+
+	s.sendAttitude()
+
+	return s, g
+}
+
+func TestStartStop(t *testing.T) {
+	ub := fakebridge.New()
+	g := New(ub, nil)
+
+	assert.NoError(t, g.Start())
+	assert.Error(t, g.Start())
+	assert.Equal(t, 1, ub.Listeners(key.KeyGimbalAttitude))
+
+	assert.NoError(t, g.Stop())
+	assert.Error(t, g.Stop())
+	assert.Equal(t, 0, ub.Listeners(key.KeyGimbalAttitude))
+}
+
+func TestMoveTo(t *testing.T) {
+	_, g := setupGimbal(t)
+	defer g.Stop()
+
+	assert.NoError(t, g.MoveTo(30, -10, time.Second))
+
+	attitude, err := g.Attitude(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(30), attitude.Yaw)
+	assert.Equal(t, float32(-10), attitude.Pitch)
+}
+
+func TestMove(t *testing.T) {
+	_, g := setupGimbal(t)
+	defer g.Stop()
+
+	assert.NoError(t, g.MoveTo(30, 0, time.Second))
+	assert.NoError(t, g.Move(-10, 5, time.Second))
+
+	attitude, err := g.Attitude(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(20), attitude.Yaw)
+	assert.Equal(t, float32(5), attitude.Pitch)
+
+	assert.NoError(t, g.Recenter(time.Second))
+}
+
+func TestMoveTo_Timeout(t *testing.T) {
+	s, g := setupGimbal(t)
+	defer g.Stop()
+
+	s.setStuck(true)
+
+	assert.Error(t, g.MoveTo(30, 0, 50*time.Millisecond))
+}
+
+func TestModes(t *testing.T) {
+	s, g := setupGimbal(t)
+	defer g.Stop()
+
+	assert.NoError(t, g.SetWorkMode(WorkModeChassisLead))
+	assert.Equal(t, &value.Uint64{Value: 2}, s.ub.Value(key.KeyGimbalWorkMode))
+
+	m, err := g.WorkMode()
+	assert.NoError(t, err)
+	assert.Equal(t, WorkModeChassisLead, m)
+
+	assert.NoError(t, g.SetControlMode(ControlModeSpeed))
+
+	c, err := g.ControlMode()
+	assert.NoError(t, err)
+	assert.Equal(t, ControlModeSpeed, c)
+	assert.Equal(t, "Speed", c.String())
+}
+
+func TestSetSpeed(t *testing.T) {
+	s, g := setupGimbal(t)
+	defer g.Stop()
+
+	assert.NoError(t, g.SetSpeed(10, 0))
+	assert.Equal(t, &value.Bool{Value: true},
+		s.ub.Value(key.KeyGimbalSpeedRotationEnabled))
+
+	assert.NoError(t, g.SetSpeed(0, 0))
+	assert.Equal(t, &value.Bool{Value: false},
+		s.ub.Value(key.KeyGimbalSpeedRotationEnabled))
+}
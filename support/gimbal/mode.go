@@ -0,0 +1,56 @@
+package gimbal
+
+import "fmt"
+
+// WorkMode is how the gimbal and the chassis move in relation to each other
+// (KeyGimbalWorkMode).
+type WorkMode uint64
+
+const (
+	// WorkModeFree moves the gimbal and the chassis independently.
+	WorkModeFree WorkMode = iota
+
+	// WorkModeGimbalLead makes the chassis follow the gimbal yaw.
+	WorkModeGimbalLead
+
+	// WorkModeChassisLead makes the gimbal follow the chassis yaw.
+	WorkModeChassisLead
+)
+
+// String returns the string representation of the WorkMode.
+func (m WorkMode) String() string {
+	switch m {
+	case WorkModeFree:
+		return "Free"
+	case WorkModeGimbalLead:
+		return "GimbalLead"
+	case WorkModeChassisLead:
+		return "ChassisLead"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint64(m))
+}
+
+// ControlMode is how the gimbal interprets control commands
+// (KeyGimbalControlMode).
+type ControlMode uint64
+
+const (
+	// ControlModeAngle controls the gimbal through angles.
+	ControlModeAngle ControlMode = iota
+
+	// ControlModeSpeed controls the gimbal through rotation speeds.
+	ControlModeSpeed
+)
+
+// String returns the string representation of the ControlMode.
+func (m ControlMode) String() string {
+	switch m {
+	case ControlModeAngle:
+		return "Angle"
+	case ControlModeSpeed:
+		return "Speed"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint64(m))
+}
@@ -0,0 +1,306 @@
+package identity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge/support/finder"
+)
+
+// storeVersion is the current version of the store file format.
+const storeVersion = 1
+
+// Robot is the identity information stored for a previously paired robot.
+type Robot struct {
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	MAC          string    `json:"mac"`
+	AppID        uint64    `json:"appId"`
+	Nickname     string    `json:"nickname,omitempty"`
+	LastKnownIP  string    `json:"lastKnownIp,omitempty"`
+	LastSeen     time.Time `json:"lastSeen"`
+}
+
+type jsonStore struct {
+	Version int      `json:"version"`
+	Robots  []*Robot `json:"robots"`
+}
+
+// Store is a persistent store of robot identities. Robots are identified by
+// their MAC address and can also be looked up by serial number. All changes
+// are immediately persisted. It is thread safe.
+type Store struct {
+	path string
+
+	m      sync.Mutex
+	robots map[string]*Robot
+}
+
+// DefaultPath returns the default path for the store file. This is
+// robots.json inside the same directory the Unity Bridge library is
+// installed to (~/.unitybridge).
+func DefaultPath() (string, error) {
+	userHomeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userHomeDir, ".unitybridge", "robots.json"), nil
+}
+
+// Open opens the store at the given path. If path is empty, DefaultPath() is
+// used. A missing file is not an error and results in an empty store.
+func Open(path string) (*Store, error) {
+	if path == "" {
+		var err error
+		path, err = DefaultPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s := &Store{
+		path:   path,
+		robots: make(map[string]*Robot),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+
+		return nil, err
+	}
+
+	var js jsonStore
+	if err = json.Unmarshal(data, &js); err != nil {
+		return nil, fmt.Errorf("error parsing store %s: %w", path, err)
+	}
+
+	if js.Version != storeVersion {
+		return nil, fmt.Errorf("unsupported store version %d in %s",
+			js.Version, path)
+	}
+
+	for _, r := range js.Robots {
+		mac, err := normalizeMAC(r.MAC)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing store %s: %w", path, err)
+		}
+
+		r.MAC = mac
+		s.robots[mac] = r
+	}
+
+	return s, nil
+}
+
+// Path returns the path of the store file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Robots returns all robots in the store, most recently seen first.
+func (s *Store) Robots() []Robot {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.sortedRobotsLocked()
+}
+
+// ByMAC returns the robot with the given MAC address and true if it is in the
+// store. Returns false otherwise.
+func (s *Store) ByMAC(mac net.HardwareAddr) (Robot, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	r, ok := s.robots[mac.String()]
+	if !ok {
+		return Robot{}, false
+	}
+
+	return *r, true
+}
+
+// BySerialNumber returns the robot with the given serial number and true if
+// it is in the store. Returns false otherwise (including when the serial
+// number is empty).
+func (s *Store) BySerialNumber(serialNumber string) (Robot, bool) {
+	if serialNumber == "" {
+		return Robot{}, false
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for _, r := range s.robots {
+		if r.SerialNumber == serialNumber {
+			return *r, true
+		}
+	}
+
+	return Robot{}, false
+}
+
+// ByNickname returns the robot with the given nickname and true if it is in
+// the store. Returns false otherwise (including when the nickname is empty).
+func (s *Store) ByNickname(nickname string) (Robot, bool) {
+	if nickname == "" {
+		return Robot{}, false
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	for _, r := range s.robots {
+		if r.Nickname == nickname {
+			return *r, true
+		}
+	}
+
+	return Robot{}, false
+}
+
+// Put adds the given robot to the store or replaces an existing robot with
+// the same MAC address and saves the store.
+func (s *Store) Put(r Robot) error {
+	mac, err := normalizeMAC(r.MAC)
+	if err != nil {
+		return err
+	}
+
+	r.MAC = mac
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.robots[mac] = &r
+
+	return s.saveLocked()
+}
+
+// PutBroadcast updates the app ID, last known IP and last seen time of the
+// robot that sent the given broadcast, adding it to the store if needed, and
+// saves the store. As broadcasts do not include it, the robot serial number
+// must be given (it is only updated if non-empty). The app ID is not updated
+// for broadcasts in pairing mode as it is not the one the robot is paired
+// with. The nickname is preserved.
+func (s *Store) PutBroadcast(b *finder.Broadcast, serialNumber string) error {
+	mac := b.SourceMac().String()
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	r, ok := s.robots[mac]
+	if !ok {
+		r = &Robot{
+			MAC: mac,
+		}
+		s.robots[mac] = r
+	}
+
+	if serialNumber != "" {
+		r.SerialNumber = serialNumber
+	}
+
+	if !b.IsPairing() {
+		r.AppID = b.AppId()
+	}
+
+	r.LastKnownIP = b.SourceIp().String()
+	r.LastSeen = time.Now()
+
+	return s.saveLocked()
+}
+
+// Remove removes the robot with the given MAC address from the store and
+// saves the store.
+func (s *Store) Remove(mac net.HardwareAddr) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.robots[mac.String()]; !ok {
+		return fmt.Errorf("no robot with MAC %s in the store", mac)
+	}
+
+	delete(s.robots, mac.String())
+
+	return s.saveLocked()
+}
+
+func (s *Store) sortedRobotsLocked() []Robot {
+	robots := make([]Robot, 0, len(s.robots))
+	for _, r := range s.robots {
+		robots = append(robots, *r)
+	}
+
+	sort.Slice(robots, func(i, j int) bool {
+		if robots[i].LastSeen.Equal(robots[j].LastSeen) {
+			return robots[i].MAC < robots[j].MAC
+		}
+
+		return robots[i].LastSeen.After(robots[j].LastSeen)
+	})
+
+	return robots
+}
+
+// saveLocked writes the store to disk. The file is written to a temporary file
+// first and then renamed so it is never left partially written. The store
+// mutex must be locked when this is called.
+func (s *Store) saveLocked() error {
+	robots := s.sortedRobotsLocked()
+
+	js := jsonStore{
+		Version: storeVersion,
+		Robots:  make([]*Robot, len(robots)),
+	}
+	for i := range robots {
+		js.Robots[i] = &robots[i]
+	}
+
+	data, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(dir, filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err = f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path)
+}
+
+func normalizeMAC(mac string) (string, error) {
+	parsedMAC, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return "", err
+	}
+
+	return parsedMAC.String(), nil
+}
@@ -0,0 +1,125 @@
+package identity
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen_MissingFile(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "robots.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, s.Robots())
+}
+
+func TestOpen_InvalidVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "robots.json")
+	err := os.WriteFile(path, []byte(`{"version":99,"robots":[]}`), 0644)
+	assert.NoError(t, err)
+
+	_, err = Open(path)
+	assert.Error(t, err)
+}
+
+func TestStore_PersistsRobots(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "robots.json")
+
+	s, err := Open(path)
+	assert.NoError(t, err)
+
+	err = s.Put(Robot{
+		SerialNumber: "3JKDH2T001ABCD",
+		MAC:          "00-11-22-33-44-55",
+		AppID:        1234,
+		Nickname:     "red",
+	})
+	assert.NoError(t, err)
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+	b, err := finder.NewBroadcast(false, net.IPv4(192, 168, 1, 2), mac, 5678)
+	assert.NoError(t, err)
+
+	// Serial number is kept when not given.
+	err = s.PutBroadcast(b, "")
+	assert.NoError(t, err)
+
+	s, err = Open(path)
+	assert.NoError(t, err)
+
+	r, ok := s.ByMAC(mac)
+	assert.True(t, ok)
+	assert.Equal(t, "3JKDH2T001ABCD", r.SerialNumber)
+	assert.Equal(t, "00:11:22:33:44:55", r.MAC)
+	assert.Equal(t, uint64(5678), r.AppID)
+	assert.Equal(t, "red", r.Nickname)
+	assert.Equal(t, "192.168.1.2", r.LastKnownIP)
+
+	r2, ok := s.BySerialNumber("3JKDH2T001ABCD")
+	assert.True(t, ok)
+	assert.Equal(t, r, r2)
+
+	err = s.Remove(mac)
+	assert.NoError(t, err)
+
+	s, err = Open(path)
+	assert.NoError(t, err)
+	assert.Empty(t, s.Robots())
+}
+
+func TestStore_PutBroadcast(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "robots.json"))
+	assert.NoError(t, err)
+
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	b, err := finder.NewBroadcast(false, net.IPv4(192, 168, 1, 2), mac, 1234)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.PutBroadcast(b, "3JKDH2T001ABCD"))
+
+	r, ok := s.BySerialNumber("3JKDH2T001ABCD")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1234), r.AppID)
+
+	// Pairing broadcasts do not carry the app ID the robot is paired with.
+	b, err = finder.NewBroadcast(true, net.IPv4(192, 168, 1, 3), mac, 0)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.PutBroadcast(b, ""))
+
+	r, ok = s.ByMAC(mac)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1234), r.AppID)
+	assert.Equal(t, "3JKDH2T001ABCD", r.SerialNumber)
+	assert.Equal(t, "192.168.1.3", r.LastKnownIP)
+
+	// A robot first seen in pairing mode has no app ID.
+	otherMAC, _ := net.ParseMAC("00:11:22:33:44:66")
+	b, err = finder.NewBroadcast(true, net.IPv4(192, 168, 1, 4), otherMAC,
+		5678)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.PutBroadcast(b, ""))
+
+	r, ok = s.ByMAC(otherMAC)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(0), r.AppID)
+}
+
+func TestStore_EmptyQueries(t *testing.T) {
+	s, err := Open(filepath.Join(t.TempDir(), "robots.json"))
+	assert.NoError(t, err)
+
+	// Neither serial number nor nickname are set.
+	assert.NoError(t, s.Put(Robot{MAC: "00:11:22:33:44:55"}))
+
+	_, ok := s.BySerialNumber("")
+	assert.False(t, ok)
+
+	_, ok = s.ByNickname("")
+	assert.False(t, ok)
+}
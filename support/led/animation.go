@@ -0,0 +1,154 @@
+package led
+
+import (
+	"fmt"
+	"time"
+)
+
+// Step is a single animation step. Its effect is applied and kept for the
+// given duration.
+type Step struct {
+	Effect   Effect
+	Duration time.Duration
+}
+
+// Animation is a timed sequence of effects.
+type Animation struct {
+	Steps []Step
+
+	// Loop makes the animation restart after its last step until it is
+	// stopped.
+	Loop bool
+}
+
+// Validate returns an error if the animation can not be played.
+func (a *Animation) Validate() error {
+	if len(a.Steps) == 0 {
+		return fmt.Errorf("animation has no steps")
+	}
+
+	for i, s := range a.Steps {
+		if err := s.Effect.Validate(); err != nil {
+			return fmt.Errorf("animation step %d: %w", i, err)
+		}
+
+		if s.Duration <= 0 {
+			return fmt.Errorf("animation step %d: invalid duration %s", i,
+				s.Duration)
+		}
+	}
+
+	return nil
+}
+
+// player plays an animation in its own goroutine.
+type player struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Play starts playing the given animation, replacing any animation already
+// playing. It returns immediately. Errors applying individual steps are
+// logged.
+func (l *LED) Play(a *Animation) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+
+	// Copy steps so changes to the given animation do not affect playback.
+	steps := append([]Step(nil), a.Steps...)
+
+	p := &player{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	l.m.Lock()
+	previous := l.player
+	l.player = p
+	l.m.Unlock()
+
+	previous.halt()
+
+	go l.run(p, steps, a.Loop)
+
+	return nil
+}
+
+// StopAnimation stops the animation currently playing, if any. The LEDs keep
+// the effect of the last applied step.
+func (l *LED) StopAnimation() {
+	l.m.Lock()
+	p := l.player
+	l.player = nil
+	l.m.Unlock()
+
+	p.halt()
+}
+
+// Playing returns true if an animation is currently playing.
+func (l *LED) Playing() bool {
+	l.m.Lock()
+	p := l.player
+	l.m.Unlock()
+
+	if p == nil {
+		return false
+	}
+
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Wait blocks until the animation currently playing, if any, finishes or is
+// stopped. Looping animations only finish when stopped.
+func (l *LED) Wait() {
+	l.m.Lock()
+	p := l.player
+	l.m.Unlock()
+
+	if p != nil {
+		<-p.done
+	}
+}
+
+func (l *LED) run(p *player, steps []Step, loop bool) {
+	defer close(p.done)
+
+	for {
+		for i, s := range steps {
+			if err := l.SetEffect(s.Effect); err != nil {
+				l.l.Error("Error applying animation step", "step", i,
+					"error", err)
+			}
+
+			t := time.NewTimer(s.Duration)
+
+			select {
+			case <-t.C:
+			case <-p.stop:
+				t.Stop()
+				return
+			}
+		}
+
+		if !loop {
+			return
+		}
+	}
+}
+
+// halt stops the player and waits for it to finish. It is a no-op on a nil
+// player. It must be called at most once per player.
+func (p *player) halt() {
+	if p == nil {
+		return
+	}
+
+	close(p.stop)
+	<-p.done
+}
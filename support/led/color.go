@@ -0,0 +1,82 @@
+package led
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Color is an RGB color.
+type Color struct {
+	R uint8
+	G uint8
+	B uint8
+}
+
+// Commonly used colors.
+var (
+	Black  = Color{}
+	White  = Color{R: 255, G: 255, B: 255}
+	Red    = Color{R: 255}
+	Green  = Color{G: 255}
+	Blue   = Color{B: 255}
+	Yellow = Color{R: 255, G: 255}
+	Cyan   = Color{G: 255, B: 255}
+	Purple = Color{R: 255, B: 255}
+)
+
+// String returns the color in #rrggbb format.
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// Component is a bitmask of robot LED components.
+type Component uint8
+
+const (
+	ComponentBottomBack Component = 1 << iota
+	ComponentBottomFront
+	ComponentBottomLeft
+	ComponentBottomRight
+	ComponentTopLeft
+	ComponentTopRight
+
+	// ComponentBottom are the LEDs on the chassis armors.
+	ComponentBottom = ComponentBottomBack | ComponentBottomFront |
+		ComponentBottomLeft | ComponentBottomRight
+
+	// ComponentTop are the LEDs on the gimbal armors.
+	ComponentTop = ComponentTopLeft | ComponentTopRight
+
+	// ComponentAll are all the robot LEDs.
+	ComponentAll = ComponentBottom | ComponentTop
+)
+
+var componentNames = []string{
+	"BottomBack",
+	"BottomFront",
+	"BottomLeft",
+	"BottomRight",
+	"TopLeft",
+	"TopRight",
+}
+
+// String returns the string representation of the Component as a "|"
+// separated list of component names.
+func (c Component) String() string {
+	if c == 0 {
+		return "None"
+	}
+
+	var names []string
+	for i, name := range componentNames {
+		if c&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	if unknown := c &^ ComponentAll; unknown != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint8(unknown)))
+	}
+
+	return strings.Join(names, "|")
+}
@@ -0,0 +1,131 @@
+package led
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// EffectMode is how a color is displayed by the LEDs.
+type EffectMode uint8
+
+const (
+	EffectModeOff EffectMode = iota
+	EffectModeSolid
+	EffectModeBreathing
+	EffectModeFlash
+	EffectModeMarquee
+)
+
+// String returns the string representation of the EffectMode.
+func (m EffectMode) String() string {
+	switch m {
+	case EffectModeOff:
+		return "Off"
+	case EffectModeSolid:
+		return "Solid"
+	case EffectModeBreathing:
+		return "Breathing"
+	case EffectModeFlash:
+		return "Flash"
+	case EffectModeMarquee:
+		return "Marquee"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(m))
+}
+
+// Effect is a light effect applied to a set of LED components. OnTime and
+// OffTime are how long the LEDs stay lit and unlit in each effect cycle (they
+// are ignored by solid effects).
+type Effect struct {
+	Components Component
+	Mode       EffectMode
+	Color      Color
+	OnTime     time.Duration
+	OffTime    time.Duration
+}
+
+// Off returns an effect that turns off the given components.
+func Off(c Component) Effect {
+	return Effect{Components: c, Mode: EffectModeOff}
+}
+
+// Solid returns an effect that lights the given components with the given
+// color.
+func Solid(c Component, color Color) Effect {
+	return Effect{Components: c, Mode: EffectModeSolid, Color: color}
+}
+
+// Breathing returns an effect that fades the given components in and out
+// with the given color. Each fade takes half the given period.
+func Breathing(c Component, color Color, period time.Duration) Effect {
+	return Effect{
+		Components: c,
+		Mode:       EffectModeBreathing,
+		Color:      color,
+		OnTime:     period / 2,
+		OffTime:    period / 2,
+	}
+}
+
+// Flash returns an effect that blinks the given components with the given
+// color and on and off times.
+func Flash(c Component, color Color, on, off time.Duration) Effect {
+	return Effect{
+		Components: c,
+		Mode:       EffectModeFlash,
+		Color:      color,
+		OnTime:     on,
+		OffTime:    off,
+	}
+}
+
+// Marquee returns an effect that scrolls the given color around the given
+// components, completing a lap in the given period. Only the top (gimbal)
+// LEDs support it.
+func Marquee(c Component, color Color, period time.Duration) Effect {
+	return Effect{
+		Components: c,
+		Mode:       EffectModeMarquee,
+		Color:      color,
+		OnTime:     period,
+	}
+}
+
+// Validate returns an error if the effect can not be sent to the robot.
+func (e Effect) Validate() error {
+	if e.Components == 0 || e.Components&^ComponentAll != 0 {
+		return fmt.Errorf("invalid LED components %s", e.Components)
+	}
+
+	if e.Mode > EffectModeMarquee {
+		return fmt.Errorf("invalid LED effect mode %s", e.Mode)
+	}
+
+	if e.Mode == EffectModeMarquee && e.Components&^ComponentTop != 0 {
+		return fmt.Errorf("marquee effect is only supported by top LEDs")
+	}
+
+	for _, d := range []time.Duration{e.OnTime, e.OffTime} {
+		if d < 0 || d.Milliseconds() > math.MaxUint16 {
+			return fmt.Errorf("invalid LED effect time %s", d)
+		}
+	}
+
+	return nil
+}
+
+func (e Effect) value() *value.LEDLightEffect {
+	return &value.LEDLightEffect{
+		Mask:       uint8(e.Components),
+		EffectMode: uint8(e.Mode),
+		R:          e.Color.R,
+		G:          e.Color.G,
+		B:          e.Color.B,
+		T1:         uint16(e.OnTime.Milliseconds()),
+		T2:         uint16(e.OffTime.Milliseconds()),
+	}
+}
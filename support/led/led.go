@@ -0,0 +1,100 @@
+package led
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// MaxBrightness is the maximum headlight brightness.
+const MaxBrightness = 100
+
+// LED controls the robot LEDs and headlights. It can also play animations
+// (timed sequences of effects). It is thread safe.
+type LED struct {
+	ub unitybridge.UnityBridge
+	l  *logger.Logger
+
+	m      sync.Mutex
+	player *player
+}
+
+// New returns a new LED instance that controls the LEDs of the robot
+// connected to the given UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger) *LED {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	return &LED{
+		ub: ub,
+		l:  l.WithGroup("led"),
+	}
+}
+
+// SetColor sets the color of all the robot LEDs.
+func (l *LED) SetColor(c Color) error {
+	return l.ub.SetKeyValueSync(key.KeyRobomasterSystemLEDColor,
+		&value.LEDColor{R: c.R, G: c.G, B: c.B})
+}
+
+// SetEffect applies the given effect to the LEDs it targets.
+func (l *LED) SetEffect(e Effect) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+
+	return l.ub.PerformActionForKeySync(key.KeyRobomasterSystemLEDLightEffect,
+		e.value())
+}
+
+// Headlights returns the left and right headlight brightness.
+func (l *LED) Headlights() (uint8, uint8, error) {
+	left, err := l.readBrightness(key.KeyRobomasterSystemLeftHeadlightBrightness)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	right, err := l.readBrightness(key.KeyRobomasterSystemRightHeadlightBrightness)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return left, right, nil
+}
+
+// SetHeadlights sets the left and right headlight brightness (0 to
+// MaxBrightness).
+func (l *LED) SetHeadlights(left, right uint8) error {
+	if left > MaxBrightness || right > MaxBrightness {
+		return fmt.Errorf("invalid headlight brightness %d/%d (must be at "+
+			"most %d)", left, right, MaxBrightness)
+	}
+
+	err := l.ub.SetKeyValueSync(key.KeyRobomasterSystemLeftHeadlightBrightness,
+		&value.Uint64{Value: uint64(left)})
+	if err != nil {
+		return err
+	}
+
+	return l.ub.SetKeyValueSync(key.KeyRobomasterSystemRightHeadlightBrightness,
+		&value.Uint64{Value: uint64(right)})
+}
+
+func (l *LED) readBrightness(k *key.Key) (uint8, error) {
+	r, err := l.ub.GetKeyValueSync(k, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if !r.Succeeded() {
+		return 0, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	return uint8(r.Value().(*value.Uint64).Value), nil
+}
@@ -0,0 +1,125 @@
+package led
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func effects(ub *fakebridge.Bridge) []*value.LEDLightEffect {
+	var effects []*value.LEDLightEffect
+	for _, a := range ub.Actions() {
+		effects = append(effects, a.Value.(*value.LEDLightEffect))
+	}
+
+	return effects
+}
+
+func TestSetColor(t *testing.T) {
+	ub := fakebridge.New()
+	l := New(ub, nil)
+
+	assert.NoError(t, l.SetColor(Purple))
+	assert.Equal(t, &value.LEDColor{R: 255, B: 255},
+		ub.Value(key.KeyRobomasterSystemLEDColor))
+	assert.Equal(t, "#ff00ff", Purple.String())
+}
+
+func TestSetEffect(t *testing.T) {
+	ub := fakebridge.New()
+	l := New(ub, nil)
+
+	assert.NoError(t, l.SetEffect(Flash(ComponentBottomFront|ComponentTopLeft,
+		Red, 100*time.Millisecond, 200*time.Millisecond)))
+	assert.NoError(t, l.SetEffect(Marquee(ComponentTop, Blue, time.Second)))
+
+	assert.Equal(t, []*value.LEDLightEffect{
+		{Mask: 0x12, EffectMode: 3, R: 255, T1: 100, T2: 200},
+		{Mask: 0x30, EffectMode: 4, B: 255, T1: 1000},
+	}, effects(ub))
+}
+
+func TestEffect_Validate(t *testing.T) {
+	assert.NoError(t, Solid(ComponentAll, White).Validate())
+	assert.NoError(t, Off(ComponentBottom).Validate())
+	assert.NoError(t, Breathing(ComponentAll, Green, time.Second).Validate())
+
+	assert.Error(t, Solid(0, White).Validate())
+	assert.Error(t, Solid(0x40, White).Validate())
+	assert.Error(t, Marquee(ComponentAll, White, time.Second).Validate())
+	assert.Error(t, Flash(ComponentAll, White, 2*time.Minute, 0).Validate())
+	assert.Error(t, Effect{Components: ComponentAll, Mode: 10}.Validate())
+}
+
+func TestComponent_String(t *testing.T) {
+	assert.Equal(t, "None", Component(0).String())
+	assert.Equal(t, "BottomBack|TopRight",
+		(ComponentBottomBack | ComponentTopRight).String())
+	assert.Equal(t, "TopLeft|0x80", Component(0x90).String())
+}
+
+func TestHeadlights(t *testing.T) {
+	ub := fakebridge.New()
+	l := New(ub, nil)
+
+	assert.NoError(t, l.SetHeadlights(10, 90))
+
+	left, right, err := l.Headlights()
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(10), left)
+	assert.Equal(t, uint8(90), right)
+
+	assert.Error(t, l.SetHeadlights(MaxBrightness+1, 0))
+}
+
+func TestPlay(t *testing.T) {
+	ub := fakebridge.New()
+	l := New(ub, nil)
+
+	assert.Error(t, l.Play(&Animation{}))
+	assert.Error(t, l.Play(&Animation{Steps: []Step{
+		{Effect: Solid(ComponentAll, Red)},
+	}}))
+
+	assert.NoError(t, l.Play(&Animation{Steps: []Step{
+		{Effect: Solid(ComponentAll, Red), Duration: 10 * time.Millisecond},
+		{Effect: Solid(ComponentAll, Green), Duration: 10 * time.Millisecond},
+		{Effect: Off(ComponentAll), Duration: 10 * time.Millisecond},
+	}}))
+
+	l.Wait()
+
+	assert.False(t, l.Playing())
+	assert.Equal(t, 3, len(ub.Actions()))
+	assert.Equal(t, uint8(EffectModeOff), effects(ub)[2].EffectMode)
+}
+
+func TestPlay_LoopAndStop(t *testing.T) {
+	ub := fakebridge.New()
+	l := New(ub, nil)
+
+	assert.NoError(t, l.Play(&Animation{
+		Steps: []Step{
+			{Effect: Solid(ComponentAll, Red), Duration: 5 * time.Millisecond},
+			{Effect: Off(ComponentAll), Duration: 5 * time.Millisecond},
+		},
+		Loop: true,
+	}))
+
+	assert.Eventually(t, func() bool {
+		return len(ub.Actions()) > 4
+	}, time.Second, 5*time.Millisecond)
+	assert.True(t, l.Playing())
+
+	l.StopAnimation()
+
+	assert.False(t, l.Playing())
+
+	count := len(ub.Actions())
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, count, len(ub.Actions()))
+}
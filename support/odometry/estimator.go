@@ -0,0 +1,393 @@
+package odometry
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/chassis"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/token"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result"
+	"github.com/brunoga/unitybridge/unity/result/value"
+)
+
+// maxSpeedInterval is the longest interval a chassis speed is integrated
+// for. Longer gaps between updates (for example, after a connection hiccup)
+// are not extrapolated.
+const maxSpeedInterval = 500 * time.Millisecond
+
+// Pose is a timestamped 2D pose. X is forward and Y is to the right of the
+// robot at the time the estimator was started (or reset) and Yaw is
+// clockwise.
+type Pose struct {
+	X    float64   // Meters.
+	Y    float64   // Meters.
+	Yaw  float64   // Degrees, in the (-180, 180] range.
+	Time time.Time // When the pose was last updated.
+}
+
+// PoseCallback is called whenever the estimated pose changes.
+type PoseCallback func(p Pose)
+
+// Option is a configuration option for an Estimator.
+type Option func(e *Estimator)
+
+// WithWheelOdometry makes the Estimator integrate the chassis velocity
+// computed from the wheel speeds reported by the ESCs (chassis.ESCKeys) with
+// the given geometry instead of the chassis speed reported by the robot
+// (KeyRobomasterChassisSpeed).
+func WithWheelOdometry(g chassis.Geometry) Option {
+	return func(e *Estimator) {
+		e.geometry = &g
+	}
+}
+
+// Estimator estimates the robot 2D pose through dead reckoning. Chassis speed
+// (or wheel speed, see WithWheelOdometry) updates are integrated between
+// updates of the robot own position
+// (KeyRobomasterMainControllerRelativePosition) and the heading comes from
+// the robot attitude (KeyRobomasterSystemAttitudeInfo) when available. It is
+// thread safe.
+type Estimator struct {
+	ub       unitybridge.UnityBridge
+	l        *logger.Logger
+	d        *support.Dispatcher[Pose]
+	geometry *chassis.Geometry // Nil if not using wheel odometry.
+
+	m       sync.Mutex
+	started bool
+	tokens  map[*key.Key]token.Token
+	pose    Pose
+
+	// Last speed update time.
+	speedTime time.Time
+
+	// Last motor information for each wheel (in chassis.ESCKeys order).
+	motors [4]*value.MotorInfo
+
+	// Last relative position and the reference for relative position
+	// updates (a relative position and the pose at the same instant).
+	lastPosition    *value.RelativePosition
+	positionRef     *value.RelativePosition
+	positionRefPose Pose
+
+	// Same as above, for attitude updates.
+	hasAttitude      bool
+	lastAttitudeYaw  float64
+	attitudeRefValid bool
+	attitudeRef      float64
+	attitudeRefYaw   float64
+}
+
+// New returns a new Estimator for the robot connected to the given
+// UnityBridge.
+func New(ub unitybridge.UnityBridge, l *logger.Logger,
+	opts ...Option) *Estimator {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	e := &Estimator{
+		ub: ub,
+		l:  l.WithGroup("odometry"),
+		d:  support.NewDispatcher[Pose](),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Start starts estimating the pose. The initial pose is the origin.
+func (e *Estimator) Start() error {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	if e.started {
+		return fmt.Errorf("estimator already started")
+	}
+
+	e.lastPosition = nil
+	e.hasAttitude = false
+	e.motors = [4]*value.MotorInfo{}
+	e.resetLocked(Pose{Time: time.Now()})
+
+	e.tokens = make(map[*key.Key]token.Token)
+
+	callbacks := map[*key.Key]result.Callback{
+		key.KeyRobomasterSystemAttitudeInfo:             e.onAttitude,
+		key.KeyRobomasterMainControllerRelativePosition: e.onRelativePosition,
+	}
+
+	if e.geometry == nil {
+		callbacks[key.KeyRobomasterChassisSpeed] = e.onChassisSpeed
+	} else {
+		for i, k := range chassis.ESCKeys {
+			callbacks[k] = e.motorCallback(i)
+		}
+	}
+
+	for k, c := range callbacks {
+		t, err := e.ub.AddKeyListener(k, c, false)
+		if err != nil {
+			return errors.Join(err, e.stopLocked())
+		}
+
+		e.tokens[k] = t
+	}
+
+	e.started = true
+
+	return nil
+}
+
+// Stop stops estimating the pose.
+func (e *Estimator) Stop() error {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	if !e.started {
+		return fmt.Errorf("estimator not started")
+	}
+
+	e.started = false
+
+	return e.stopLocked()
+}
+
+// Pose returns the current estimated pose.
+func (e *Estimator) Pose() Pose {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	return e.pose
+}
+
+// Reset sets the current estimated pose to the given one. Subsequent updates
+// are relative to it.
+func (e *Estimator) Reset(p Pose) {
+	e.m.Lock()
+
+	if p.Time.IsZero() {
+		p.Time = time.Now()
+	}
+
+	p.Yaw = normalizeAngle(p.Yaw)
+
+	e.resetLocked(p)
+	e.d.Dispatch(p)
+
+	e.m.Unlock()
+}
+
+// AddListener adds a callback to be called whenever the estimated pose
+// changes. Poses are delivered in the order they were estimated. It returns a
+// token that can be used to remove it later.
+func (e *Estimator) AddListener(c PoseCallback) (token.Token, error) {
+	if c == nil {
+		return 0, fmt.Errorf("callback cannot be nil")
+	}
+
+	return e.d.AddListener(c)
+}
+
+// RemoveListener removes the callback associated with the given token.
+func (e *Estimator) RemoveListener(t token.Token) error {
+	return e.d.RemoveListener(t)
+}
+
+func (e *Estimator) stopLocked() error {
+	var err error
+	for k, t := range e.tokens {
+		err = errors.Join(err, e.ub.RemoveKeyListener(k, t))
+	}
+
+	e.tokens = nil
+
+	return err
+}
+
+// resetLocked sets the current pose and makes it the reference for
+// subsequent position and attitude updates.
+func (e *Estimator) resetLocked(p Pose) {
+	e.pose = p
+	e.speedTime = time.Time{}
+
+	e.positionRef = e.lastPosition
+	e.positionRefPose = p
+
+	e.attitudeRefValid = e.hasAttitude
+	e.attitudeRef = e.lastAttitudeYaw
+	e.attitudeRefYaw = p.Yaw
+}
+
+func (e *Estimator) onChassisSpeed(r *result.Result) {
+	if !r.Succeeded() {
+		return
+	}
+
+	speed := r.Value().(*value.ChassisSpeed)
+
+	e.m.Lock()
+
+	e.integrateSpeedLocked(chassis.Velocity{
+		X: float64(speed.X),
+		Y: float64(speed.Y),
+		Z: float64(speed.Z),
+	})
+
+	e.m.Unlock()
+}
+
+// motorCallback returns the callback for the motor information of the wheel
+// with the given index (in chassis.ESCKeys order).
+func (e *Estimator) motorCallback(i int) result.Callback {
+	return func(r *result.Result) {
+		if !r.Succeeded() {
+			return
+		}
+
+		e.m.Lock()
+		defer e.m.Unlock()
+
+		e.motors[i] = r.Value().(*value.MotorInfo)
+
+		for _, m := range e.motors {
+			if m == nil {
+				// Speeds for all wheels are needed.
+				return
+			}
+		}
+
+		e.integrateSpeedLocked(e.geometry.Forward(
+			chassis.WheelSpeedsFromMotors(e.motors)))
+	}
+}
+
+// integrateSpeedLocked integrates the given chassis velocity since the last
+// speed update.
+func (e *Estimator) integrateSpeedLocked(v chassis.Velocity) {
+	now := time.Now()
+
+	dt := now.Sub(e.speedTime)
+	e.speedTime = now
+
+	if dt <= 0 || dt > maxSpeedInterval {
+		return
+	}
+
+	e.pose = integrate(e.pose, v.X, v.Y, v.Z, dt.Seconds(), !e.hasAttitude)
+	e.pose.Time = now
+	e.d.Dispatch(e.pose)
+}
+
+func (e *Estimator) onAttitude(r *result.Result) {
+	if !r.Succeeded() {
+		return
+	}
+
+	attitude := r.Value().(*value.AttitudeInfo)
+
+	e.m.Lock()
+
+	e.hasAttitude = true
+	e.lastAttitudeYaw = float64(attitude.Yaw)
+
+	if !e.attitudeRefValid {
+		// First attitude since the last reset. Use it as reference.
+		e.attitudeRefValid = true
+		e.attitudeRef = e.lastAttitudeYaw
+		e.attitudeRefYaw = e.pose.Yaw
+	}
+
+	e.pose.Yaw = normalizeAngle(e.attitudeRefYaw + e.lastAttitudeYaw -
+		e.attitudeRef)
+	e.pose.Time = time.Now()
+	e.d.Dispatch(e.pose)
+
+	e.m.Unlock()
+}
+
+func (e *Estimator) onRelativePosition(r *result.Result) {
+	if !r.Succeeded() {
+		return
+	}
+
+	position := r.Value().(*value.RelativePosition)
+
+	e.m.Lock()
+
+	e.lastPosition = position
+
+	if e.positionRef == nil {
+		// First position since the last reset. Use it as reference.
+		e.positionRef = position
+		e.positionRefPose = e.pose
+		e.m.Unlock()
+		return
+	}
+
+	// Displacement since the reference, in the frame of the robot at the
+	// reference.
+	dx, dy := rotate(float64(position.X-e.positionRef.X),
+		float64(position.Y-e.positionRef.Y), -float64(e.positionRef.Z))
+
+	// Same displacement in the pose frame.
+	dx, dy = rotate(dx, dy, e.positionRefPose.Yaw)
+
+	e.pose.X = e.positionRefPose.X + dx
+	e.pose.Y = e.positionRefPose.Y + dy
+	e.pose.Time = time.Now()
+	e.d.Dispatch(e.pose)
+
+	e.m.Unlock()
+}
+
+// integrate returns the pose after moving at the given body velocity (vx and
+// vy in m/s, wz in degrees/s) for dt seconds. The yaw is only integrated if
+// integrateYaw is true.
+func integrate(p Pose, vx, vy, wz, dt float64, integrateYaw bool) Pose {
+	yaw := p.Yaw
+	if integrateYaw {
+		// Use the mid-point heading for a better approximation of arcs.
+		yaw = p.Yaw + wz*dt/2
+		p.Yaw = normalizeAngle(p.Yaw + wz*dt)
+	}
+
+	dx, dy := rotate(vx*dt, vy*dt, yaw)
+	p.X += dx
+	p.Y += dy
+
+	return p
+}
+
+// rotate rotates the given vector clockwise by the given angle (in degrees).
+// With X forward and Y right, this turns body frame vectors into world frame
+// ones for a robot with the given yaw.
+func rotate(x, y, angle float64) (float64, float64) {
+	sin, cos := math.Sincos(angle * math.Pi / 180)
+
+	return x*cos - y*sin, x*sin + y*cos
+}
+
+// normalizeAngle returns the given angle (in degrees) in the (-180, 180]
+// range.
+func normalizeAngle(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+	if angle <= -180 {
+		angle += 360
+	} else if angle > 180 {
+		angle -= 360
+	}
+
+	return angle
+}
@@ -0,0 +1,193 @@
+package odometry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/support/chassis"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_StartStop(t *testing.T) {
+	ub := fakebridge.New()
+	e := New(ub, nil)
+
+	keys := []*key.Key{
+		key.KeyRobomasterMainControllerRelativePosition,
+		key.KeyRobomasterSystemAttitudeInfo,
+		key.KeyRobomasterChassisSpeed,
+	}
+
+	assert.NoError(t, e.Start())
+	assert.Error(t, e.Start())
+	for _, k := range keys {
+		assert.Equal(t, 1, ub.Listeners(k), k)
+	}
+
+	assert.NoError(t, e.Stop())
+	assert.Error(t, e.Stop())
+	for _, k := range keys {
+		assert.Equal(t, 0, ub.Listeners(k), k)
+	}
+}
+
+func TestEstimator_RelativePositionAndAttitude(t *testing.T) {
+	ub := fakebridge.New()
+	e := New(ub, nil)
+	assert.NoError(t, e.Start())
+	defer e.Stop()
+
+	// Robot powered on facing 90 degrees away from where we want our origin.
+	ub.Send(key.KeyRobomasterSystemAttitudeInfo, &value.AttitudeInfo{Yaw: 90})
+	ub.Send(key.KeyRobomasterMainControllerRelativePosition,
+		&value.RelativePosition{X: 1, Y: 1, Z: 90})
+
+	// Robot moves forward 1 meter (along its Y axis of the robot frame).
+	ub.Send(key.KeyRobomasterMainControllerRelativePosition,
+		&value.RelativePosition{X: 1, Y: 2, Z: 90})
+
+	p := e.Pose()
+	assert.InDelta(t, 1, p.X, 1e-6)
+	assert.InDelta(t, 0, p.Y, 1e-6)
+	assert.InDelta(t, 0, p.Yaw, 1e-6)
+
+	// Then turns right.
+	ub.Send(key.KeyRobomasterSystemAttitudeInfo, &value.AttitudeInfo{Yaw: 135})
+	assert.InDelta(t, 45, e.Pose().Yaw, 1e-6)
+
+	// Reset and move again.
+	e.Reset(Pose{X: 10, Y: 10, Yaw: 180})
+
+	ub.Send(key.KeyRobomasterMainControllerRelativePosition,
+		&value.RelativePosition{X: 1, Y: 3, Z: 90})
+
+	p = e.Pose()
+	assert.InDelta(t, 9, p.X, 1e-6)
+	assert.InDelta(t, 10, p.Y, 1e-6)
+	assert.InDelta(t, 180, p.Yaw, 1e-6)
+}
+
+func TestEstimator_ChassisSpeed(t *testing.T) {
+	ub := fakebridge.New()
+	e := New(ub, nil)
+	assert.NoError(t, e.Start())
+	defer e.Stop()
+
+	ch := make(chan Pose, 10)
+	tk, err := e.AddListener(func(p Pose) {
+		ch <- p
+	})
+	assert.NoError(t, err)
+
+	speed := &value.ChassisSpeed{X: 1}
+
+	// The first update only sets the time reference.
+	ub.Send(key.KeyRobomasterChassisSpeed, speed)
+	time.Sleep(50 * time.Millisecond)
+	ub.Send(key.KeyRobomasterChassisSpeed, speed)
+
+	select {
+	case p := <-ch:
+		assert.InDelta(t, 0.05, p.X, 0.04)
+		assert.Greater(t, p.X, 0.0)
+		assert.Equal(t, 0.0, p.Y)
+	case <-time.After(time.Second):
+		t.Fatal("no pose update")
+	}
+
+	assert.NoError(t, e.RemoveListener(tk))
+	assert.Error(t, e.RemoveListener(tk))
+}
+
+func TestEstimator_OrderedListeners(t *testing.T) {
+	e := New(fakebridge.New(), nil)
+
+	ch := make(chan Pose, 100)
+	_, err := e.AddListener(func(p Pose) {
+		ch <- p
+	})
+	assert.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		e.Reset(Pose{X: float64(i)})
+	}
+
+	for i := 0; i < 100; i++ {
+		select {
+		case p := <-ch:
+			assert.Equal(t, float64(i), p.X)
+		case <-time.After(time.Second):
+			t.Fatal("no pose update")
+		}
+	}
+}
+
+func TestEstimator_WheelOdometry(t *testing.T) {
+	ub := fakebridge.New()
+	e := New(ub, nil, WithWheelOdometry(chassis.DefaultGeometry()))
+	assert.NoError(t, e.Start())
+	defer e.Stop()
+
+	assert.Equal(t, 0, ub.Listeners(key.KeyRobomasterChassisSpeed))
+	for _, k := range chassis.ESCKeys {
+		assert.Equal(t, 1, ub.Listeners(k), k)
+	}
+
+	// All wheels rolling forward at 1 m/s.
+	rpm := chassis.DefaultGeometry().Inverse(chassis.Velocity{X: 1}).Value()
+	motors := []*value.MotorInfo{
+		{Speed: rpm.FrontRight},
+		{Speed: rpm.FrontLeft},
+		{Speed: rpm.RearLeft},
+		{Speed: rpm.RearRight},
+	}
+
+	// Nothing is integrated until speeds for all wheels are known and the
+	// first full update only sets the time reference.
+	for i, k := range chassis.ESCKeys {
+		ub.Send(k, motors[i])
+	}
+	assert.Equal(t, 0.0, e.Pose().X)
+
+	time.Sleep(50 * time.Millisecond)
+	ub.Send(chassis.ESCKeys[0], motors[0])
+
+	p := e.Pose()
+	assert.InDelta(t, 0.05, p.X, 0.04)
+	assert.Greater(t, p.X, 0.0)
+	assert.InDelta(t, 0, p.Y, 1e-3)
+	assert.InDelta(t, 0, p.Yaw, 1e-3)
+
+	assert.NoError(t, e.Stop())
+	for _, k := range chassis.ESCKeys {
+		assert.Equal(t, 0, ub.Listeners(k), k)
+	}
+	assert.NoError(t, e.Start())
+}
+
+func TestIntegrate(t *testing.T) {
+	// Quarter turn arc.
+	p := Pose{}
+	for i := 0; i < 1000; i++ {
+		p = integrate(p, 1, 0, 90, 0.001, true)
+	}
+
+	assert.InDelta(t, 90, p.Yaw, 1e-6)
+	assert.Greater(t, p.X, 0.0)
+	assert.Greater(t, p.Y, 0.0)
+
+	// Yaw is kept when not integrated.
+	p = integrate(Pose{Yaw: 90}, 1, 0, 90, 1, false)
+	assert.Equal(t, 90.0, p.Yaw)
+	assert.InDelta(t, 0, p.X, 1e-9)
+	assert.InDelta(t, 1, p.Y, 1e-9)
+}
+
+func TestNormalizeAngle(t *testing.T) {
+	assert.Equal(t, 180.0, normalizeAngle(-180))
+	assert.Equal(t, -90.0, normalizeAngle(270))
+	assert.Equal(t, 10.0, normalizeAngle(730))
+}
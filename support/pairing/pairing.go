@@ -0,0 +1,142 @@
+package pairing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/brunoga/unitybridge/support"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/brunoga/unitybridge/support/logger"
+	"github.com/brunoga/unitybridge/support/qrcode"
+)
+
+// ackInterval is the interval between ACKs sent to a robot that is still in
+// pairing mode.
+const ackInterval = 1 * time.Second
+
+// Pairer runs the full pairing workflow for a Robomaster robot: it generates
+// the QR code the robot must scan, waits for the robot pairing request,
+// acknowledges it and waits for the robot to show up using the new app ID.
+type Pairer struct {
+	appID      uint64
+	qrCode     *qrcode.QRCode
+	finderOpts []finder.Option
+
+	l *logger.Logger
+}
+
+// New returns a new Pairer instance that pairs robots with the given app ID
+// and network parameters. If appID is zero, a random one is generated with
+// support.GenerateAppID. The given finder options are used to configure
+// robot discovery.
+func New(appID uint64, countryCode, ssID, password, bssID string,
+	l *logger.Logger, opts ...finder.Option) (*Pairer, error) {
+	if l == nil {
+		l = logger.New(slog.LevelError)
+	}
+
+	if appID == 0 {
+		var err error
+		appID, err = support.GenerateAppID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	qrCode, err := qrcode.New(appID, countryCode, ssID, password, bssID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pairer{
+		appID:      appID,
+		qrCode:     qrCode,
+		finderOpts: opts,
+		l:          l.WithGroup("pairing"),
+	}, nil
+}
+
+// AppID returns the app ID robots are paired with.
+func (p *Pairer) AppID() uint64 {
+	return p.appID
+}
+
+// QRCode returns the QR code that must be shown to the robot.
+func (p *Pairer) QRCode() *qrcode.QRCode {
+	return p.qrCode
+}
+
+// Pair runs the pairing workflow until a robot is paired or the given context
+// is done. The given callback (if non-nil) is called synchronously on every
+// state change so, for example, the QR code can be shown when the state is
+// StateShowQRCode. Returns the broadcast sent by the paired robot on success.
+func (p *Pairer) Pair(ctx context.Context, cb ProgressCallback) (
+	*finder.Broadcast, error) {
+	var robot *finder.Broadcast
+
+	setState := func(state State) {
+		p.l.Debug("Pairing state changed", "state", state, "robot", robot)
+		if cb != nil {
+			cb(state, robot)
+		}
+	}
+
+	f := finder.New(0, p.l, p.finderOpts...)
+
+	ch := make(chan *finder.Broadcast)
+	err := f.StartFinding(ch)
+	if err != nil {
+		setState(StateFailed)
+		return nil, err
+	}
+	defer f.StopFinding()
+
+	setState(StateShowQRCode)
+	setState(StateWaitingForPairingRequest)
+
+	ticker := time.NewTicker(ackInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			setState(StateFailed)
+			return nil, fmt.Errorf("pairing not completed: %w", ctx.Err())
+		case b := <-ch:
+			switch {
+			case robot == nil && b.IsPairing() && (b.AppId() == p.appID ||
+				b.AppId() == support.AnyAppID):
+				robot = b
+
+				setState(StateAcknowledging)
+
+				if err := f.SendACK(robot.SourceIp(), p.appID); err != nil {
+					setState(StateFailed)
+					return nil, err
+				}
+
+				setState(StateWaitingForRobot)
+			case robot != nil && !b.IsPairing() && b.AppId() == p.appID &&
+				bytes.Equal(b.SourceMac(), robot.SourceMac()):
+				robot = b
+
+				setState(StatePaired)
+
+				return robot, nil
+			}
+		case <-ticker.C:
+			if robot == nil {
+				continue
+			}
+
+			// Robot might not have received the previous ACK (it is UDP
+			// after all) so keep sending it until it shows up.
+			if err := f.SendACK(robot.SourceIp(), p.appID); err != nil {
+				p.l.Warn("Error resending ACK", "robot", robot, "err", err)
+			}
+		}
+	}
+}
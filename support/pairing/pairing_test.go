@@ -0,0 +1,170 @@
+package pairing
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brunoga/unitybridge/internal/nettest"
+	"github.com/brunoga/unitybridge/support/finder"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAppID = 0x0123456789abcdef
+
+func newTestBroadcast(t *testing.T, isPairing bool,
+	appID uint64) *finder.Broadcast {
+	mac, _ := net.ParseMAC("00:11:22:33:44:55")
+
+	b, err := finder.NewBroadcast(isPairing, net.IPv4(127, 0, 0, 1), mac,
+		appID)
+	assert.NoError(t, err)
+
+	return b
+}
+
+// testPairer returns a Pairer for the test app ID and a beacon target for
+// broadcasts it will receive. ACKs are sent to the given port.
+func testPairer(t *testing.T, ackPort int) (*Pairer, string) {
+	port := nettest.FreeUDPPort(t)
+
+	p, err := New(testAppID, "BR", "ssid", "password", "", nil,
+		finder.WithPort(port), finder.WithACKPort(ackPort))
+	assert.NoError(t, err)
+
+	return p, fmt.Sprintf("127.0.0.1:%d", port)
+}
+
+// stateRecorder records all states reported to a ProgressCallback.
+type stateRecorder struct {
+	m      sync.Mutex
+	states []State
+}
+
+func (r *stateRecorder) callback(state State, b *finder.Broadcast) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	r.states = append(r.states, state)
+}
+
+func (r *stateRecorder) get() []State {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	return append([]State(nil), r.states...)
+}
+
+func TestPair_Succeeded(t *testing.T) {
+	ackPort := nettest.FreeUDPPort(t)
+
+	p, target := testPairer(t, ackPort)
+	assert.Equal(t, uint64(testAppID), p.AppID())
+	assert.NotNil(t, p.QRCode())
+
+	bc := finder.NewBeacon(newTestBroadcast(t, true, 0), nil,
+		finder.WithBeaconTarget(target),
+		finder.WithBeaconACKAddress(fmt.Sprintf(":%d", ackPort)),
+		finder.WithBeaconInterval(10*time.Millisecond))
+	assert.NoError(t, bc.Start())
+	defer bc.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	r := &stateRecorder{}
+
+	b, err := p.Pair(ctx, r.callback)
+	assert.NoError(t, err)
+	assert.False(t, b.IsPairing())
+	assert.Equal(t, uint64(testAppID), b.AppId())
+
+	assert.Equal(t, []State{
+		StateShowQRCode,
+		StateWaitingForPairingRequest,
+		StateAcknowledging,
+		StateWaitingForRobot,
+		StatePaired,
+	}, r.get())
+}
+
+func TestPair_Timeout(t *testing.T) {
+	p, _ := testPairer(t, nettest.FreeUDPPort(t))
+
+	ctx, cancel := context.WithTimeout(context.Background(),
+		50*time.Millisecond)
+	defer cancel()
+
+	r := &stateRecorder{}
+
+	_, err := p.Pair(ctx, r.callback)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	assert.Equal(t, []State{
+		StateShowQRCode,
+		StateWaitingForPairingRequest,
+		StateFailed,
+	}, r.get())
+}
+
+func TestPair_ResendsACK(t *testing.T) {
+	ackPort := nettest.FreeUDPPort(t)
+
+	p, target := testPairer(t, ackPort)
+
+	// The beacon does not listen for ACKs on the port the pairer sends them
+	// to, so the robot never leaves pairing mode by itself.
+	bc := finder.NewBeacon(newTestBroadcast(t, true, 0), nil,
+		finder.WithBeaconTarget(target),
+		finder.WithBeaconACKAddress(fmt.Sprintf(":%d",
+			nettest.FreeUDPPort(t))),
+		finder.WithBeaconInterval(10*time.Millisecond))
+	assert.NoError(t, bc.Start())
+	defer bc.Stop()
+
+	acks := make(chan uint64, 10)
+
+	cb := func(state State, b *finder.Broadcast) {
+		if state != StateWaitingForRobot {
+			return
+		}
+
+		// The first ACK was already sent (and lost). Start listening for the
+		// ones resent.
+		conn, err := net.ListenUDP("udp4", &net.UDPAddr{
+			IP:   net.IPv4(127, 0, 0, 1),
+			Port: ackPort,
+		})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+
+			buf := make([]byte, 8)
+			if _, err := conn.Read(buf); err == nil {
+				acks <- binary.LittleEndian.Uint64(buf)
+			}
+		}()
+	}
+
+	go func() {
+		select {
+		case appID := <-acks:
+			bc.SetBroadcast(newTestBroadcast(t, false, appID))
+		case <-time.After(5 * time.Second):
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	b, err := p.Pair(ctx, cb)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(testAppID), b.AppId())
+}
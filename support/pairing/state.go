@@ -0,0 +1,40 @@
+package pairing
+
+import "github.com/brunoga/unitybridge/support/finder"
+
+// State is the current state of a pairing workflow.
+type State int
+
+const (
+	StateShowQRCode               State = iota // QR code must be shown to the robot.
+	StateWaitingForPairingRequest              // Waiting for a robot in pairing mode.
+	StateAcknowledging                         // Sending ACK to the robot.
+	StateWaitingForRobot                       // Waiting for the robot to use the app ID.
+	StatePaired                                // Robot paired successfully.
+	StateFailed                                // Pairing failed.
+)
+
+// String returns the string representation of the State.
+func (s State) String() string {
+	switch s {
+	case StateShowQRCode:
+		return "ShowQRCode"
+	case StateWaitingForPairingRequest:
+		return "WaitingForPairingRequest"
+	case StateAcknowledging:
+		return "Acknowledging"
+	case StateWaitingForRobot:
+		return "WaitingForRobot"
+	case StatePaired:
+		return "Paired"
+	case StateFailed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// ProgressCallback is the type of the callback function that will be called
+// whenever the pairing workflow changes state. The given broadcast is the last
+// one received from the robot being paired (nil if no robot was found yet).
+type ProgressCallback func(state State, b *finder.Broadcast)
@@ -4,13 +4,48 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"image"
+	"io"
 	"net"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/brunoga/unitybridge/support"
+	"github.com/makiuchi-d/gozxing"
 	"github.com/skip2/go-qrcode"
+
+	zxingqrcode "github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// The message starts with 16 bits of metadata. The lower 6 bits are the SSID
+// length, the next 5 bits are the password length and the next bit tells if
+// a BSSID is present. The upper 4 bits are the message format version and
+// must be zero. Lengths are in bytes (not characters).
+const (
+	metadataSsIDLenBits     = 6
+	metadataPasswordLenBits = 5
+
+	metadataPasswordLenShift = metadataSsIDLenBits
+	metadataHasBssIDShift    = metadataPasswordLenShift + metadataPasswordLenBits
+	metadataVersionShift     = metadataHasBssIDShift + 1
+
+	// MaxSsIDLen is the maximum SSID length, in bytes.
+	MaxSsIDLen = 1<<metadataSsIDLenBits - 1
+
+	// MaxPasswordLen is the maximum password length, in bytes.
+	MaxPasswordLen = 1<<metadataPasswordLenBits - 1
+
+	// messageVersion is the only supported message format version.
+	messageVersion = 0
+
+	// Fixed size fields.
+	metadataLen    = 2
+	appIDLen       = 8
+	countryCodeLen = 2
+	bssIDLen       = 12 // Hex encoded, no separators.
+	headerLen      = metadataLen + appIDLen + countryCodeLen
 )
 
 // QRCode handles generating and parsing the data for the Robomaster connection
@@ -28,18 +63,18 @@ type QRCode struct {
 func New(appID uint64,
 	countryCode, ssID, password, bssID string) (*QRCode, error) {
 	trimmedCountryCode := strings.TrimSpace(countryCode)
-	if len(trimmedCountryCode) != 2 {
-		return nil, fmt.Errorf("country code must be 2 characters")
+	if err := validateCountryCode(trimmedCountryCode); err != nil {
+		return nil, err
 	}
 
 	trimmedSsID := strings.TrimSpace(ssID)
-	if len(trimmedSsID) == 0 {
-		return nil, fmt.Errorf("SSID must be non-empty")
+	if err := validateSsID(trimmedSsID); err != nil {
+		return nil, err
 	}
 
 	trimmedPassword := strings.TrimSpace(password)
-	if len(trimmedPassword) == 0 {
-		return nil, fmt.Errorf("password must be non-empty")
+	if err := validatePassword(trimmedPassword); err != nil {
+		return nil, err
 	}
 
 	var resultBssID *net.HardwareAddr
@@ -67,7 +102,9 @@ func New(appID uint64,
 
 // NewFromMessage parses the given message and returns a QRCode instance based
 // on it. This message is what you got if you use a normal QRCode reader to
-// read the Robomaster app generated QRCode.
+// read the Robomaster app generated QRCode. Malformed messages result in a
+// descriptive error. For any QRCode q created with New,
+// NewFromMessage(q.Message()) returns a QRCode equal to q.
 func NewFromMessage(message string) (*QRCode, error) {
 	q := &QRCode{}
 	err := q.decodeMessage(message)
@@ -78,6 +115,27 @@ func NewFromMessage(message string) (*QRCode, error) {
 	return q, nil
 }
 
+// NewFromImage finds and decodes a QR code in the given image and returns a
+// QRCode instance based on it. This can be used, for example, to inspect a
+// photo or screenshot of a QR code generated by the Robomaster app.
+func NewFromImage(img image.Image) (*QRCode, error) {
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("error processing image: %w", err)
+	}
+
+	hints := map[gozxing.DecodeHintType]interface{}{
+		gozxing.DecodeHintType_TRY_HARDER: true,
+	}
+
+	result, err := zxingqrcode.NewQRCodeReader().Decode(bitmap, hints)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding QR code in image: %w", err)
+	}
+
+	return NewFromMessage(result.GetText())
+}
+
 // AppID returns the app ID for this QRCode.
 func (q *QRCode) AppID() uint64 {
 	return q.appID
@@ -117,10 +175,23 @@ func (q *QRCode) Message() string {
 	return q.encodeMessage()
 }
 
+// Polarity selects how QR code modules are drawn when rendering to text.
+type Polarity int
+
+const (
+	// PolarityDarkBackground draws the light modules, so the QR code renders
+	// correctly on terminals with a dark background.
+	PolarityDarkBackground Polarity = iota
+
+	// PolarityLightBackground draws the dark modules, so the QR code renders
+	// correctly on terminals with a light background.
+	PolarityLightBackground
+)
+
 // Image returns an size X size image.Image that represents the QRCode instance.
 // This image is readable by a Robomaster robot.
 func (q *QRCode) Image(size int) (image.Image, error) {
-	qrc, err := qrcode.New(q.encodeMessage(), qrcode.Medium)
+	qrc, err := q.qrCode()
 	if err != nil {
 		return nil, err
 	}
@@ -128,10 +199,57 @@ func (q *QRCode) Image(size int) (image.Image, error) {
 	return qrc.Image(size), nil
 }
 
+// WritePNG writes a size X size PNG image that represents the QRCode instance
+// to the given writer.
+func (q *QRCode) WritePNG(w io.Writer, size int) error {
+	qrc, err := q.qrCode()
+	if err != nil {
+		return err
+	}
+
+	return qrc.Write(size, w)
+}
+
+// WriteSVG writes a size X size SVG image that represents the QRCode instance
+// to the given writer.
+func (q *QRCode) WriteSVG(w io.Writer, size int) error {
+	qrc, err := q.qrCode()
+	if err != nil {
+		return err
+	}
+
+	bitmap := qrc.Bitmap()
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" `+
+		`width="%d" height="%d" viewBox="0 0 %d %d" `+
+		`shape-rendering="crispEdges">`+"\n", size, size, len(bitmap),
+		len(bitmap))
+	fmt.Fprintf(&sb, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n",
+		len(bitmap), len(bitmap))
+
+	sb.WriteString(`<path fill="#000000" d="`)
+	for y, line := range bitmap {
+		for x, black := range line {
+			if black {
+				fmt.Fprintf(&sb, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+	sb.WriteString(`"/>` + "\n")
+
+	sb.WriteString("</svg>\n")
+
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
 // Text returns a string representation of the QRCode instance that can be
 // printed to the console. This should be readable by a Robomaster robot.
 func (q *QRCode) Text() (string, error) {
-	qrc, err := qrcode.New(q.encodeMessage(), qrcode.Medium)
+	qrc, err := q.qrCode()
 	if err != nil {
 		return "", err
 	}
@@ -151,6 +269,55 @@ func (q *QRCode) Text() (string, error) {
 	return sb.String(), nil
 }
 
+// CompactText returns a string representation of the QRCode instance that can
+// be printed to the console using Unicode half-block characters, so each
+// character represents two vertically stacked modules. This results in a
+// rendering that is a quarter of the size of the one returned by Text(). The
+// given polarity must match the terminal background.
+func (q *QRCode) CompactText(polarity Polarity) (string, error) {
+	qrc, err := q.qrCode()
+	if err != nil {
+		return "", err
+	}
+
+	bitmap := qrc.Bitmap()
+
+	drawn := func(y, x int) bool {
+		if y >= len(bitmap) {
+			// Odd number of lines. Pad with a light line.
+			return polarity == PolarityDarkBackground
+		}
+
+		return bitmap[y][x] == (polarity == PolarityLightBackground)
+	}
+
+	var sb strings.Builder
+	for y := 0; y < len(bitmap); y += 2 {
+		for x := range bitmap[y] {
+			top := drawn(y, x)
+			bottom := drawn(y+1, x)
+
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), nil
+}
+
+func (q *QRCode) qrCode() (*qrcode.QRCode, error) {
+	return qrcode.New(q.encodeMessage(), qrcode.Medium)
+}
+
 func (q *QRCode) encodeMessage() string {
 	var b bytes.Buffer
 
@@ -162,7 +329,8 @@ func (q *QRCode) encodeMessage() string {
 		hasBssId = uint16(1)
 	}
 
-	metadata := (hasBssId << 11) | (uint16(len(bytesPassword)) << 6) |
+	metadata := (hasBssId << metadataHasBssIDShift) |
+		(uint16(len(bytesPassword)) << metadataPasswordLenShift) |
 		uint16(len(bytesSsid))
 
 	data1 := make([]byte, 2)
@@ -192,37 +360,125 @@ func (q *QRCode) encodeMessage() string {
 }
 
 func (q *QRCode) decodeMessage(message string) error {
-	data, err := base64.StdEncoding.DecodeString(message)
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(message))
 	if err != nil {
-		return err
+		return fmt.Errorf("message is not valid base64: %w", err)
+	}
+
+	if len(data) < headerLen {
+		return fmt.Errorf("message too short: got %d bytes, need at least %d",
+			len(data), headerLen)
 	}
 
 	support.SimpleEncryptDecrypt(data)
 
 	metadata := binary.LittleEndian.Uint16(data)
 
-	hasBssId := metadata >> 11
-	lenPassword := (metadata & 0b0000001111111111) >> 6
-	lenSsId := (metadata & 0b0000000001111111)
+	version := metadata >> metadataVersionShift
+	if version != messageVersion {
+		return fmt.Errorf("unsupported message version %d", version)
+	}
 
-	q.appID = binary.LittleEndian.Uint64(data[2:])
+	hasBssID := (metadata>>metadataHasBssIDShift)&1 != 0
+	lenPassword := int(metadata>>metadataPasswordLenShift) & MaxPasswordLen
+	lenSsID := int(metadata) & MaxSsIDLen
 
-	q.countryCode = string(data[10:12])
+	expectedLen := headerLen + lenSsID + lenPassword
+	if hasBssID {
+		expectedLen += bssIDLen
+	}
+
+	if len(data) != expectedLen {
+		return fmt.Errorf("unexpected message length: got %d bytes, metadata "+
+			"requires %d", len(data), expectedLen)
+	}
 
-	q.ssID = string(data[12 : 12+lenSsId])
+	appID := binary.LittleEndian.Uint64(data[metadataLen:])
 
-	q.password = string(data[12+lenSsId : 12+lenSsId+lenPassword])
+	offset := metadataLen + appIDLen
 
-	if hasBssId != 0 {
-		parsedBssId, err := net.ParseMAC(
-			string(data[12+lenSsId+lenPassword : 12+lenSsId+lenPassword+12]))
+	countryCode := string(data[offset : offset+countryCodeLen])
+	if err := validateCountryCode(countryCode); err != nil {
+		return err
+	}
+	offset += countryCodeLen
+
+	ssID := string(data[offset : offset+lenSsID])
+	if err := validateSsID(ssID); err != nil {
+		return err
+	}
+	offset += lenSsID
+
+	password := string(data[offset : offset+lenPassword])
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+	offset += lenPassword
+
+	var bssID *net.HardwareAddr
+	if hasBssID {
+		parsedBssID, err := hex.DecodeString(string(data[offset : offset+bssIDLen]))
 		if err != nil {
-			return err
+			return fmt.Errorf("invalid BSSID: %w", err)
 		}
 
-		q.bssID = &parsedBssId
-	} else {
-		q.bssID = nil
+		hardwareAddr := net.HardwareAddr(parsedBssID)
+		bssID = &hardwareAddr
+	}
+
+	q.appID = appID
+	q.countryCode = countryCode
+	q.ssID = ssID
+	q.password = password
+	q.bssID = bssID
+
+	return nil
+}
+
+func validateCountryCode(countryCode string) error {
+	if len(countryCode) != countryCodeLen {
+		return fmt.Errorf("country code must be 2 characters")
+	}
+
+	for _, c := range countryCode {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return fmt.Errorf("country code must only contain letters: %q",
+				countryCode)
+		}
+	}
+
+	return nil
+}
+
+func validateSsID(ssID string) error {
+	if len(ssID) == 0 {
+		return fmt.Errorf("SSID must be non-empty")
+	}
+
+	if len(ssID) > MaxSsIDLen {
+		return fmt.Errorf("SSID must have at most %d bytes (got %d)",
+			MaxSsIDLen, len(ssID))
+	}
+
+	if !utf8.ValidString(ssID) {
+		return fmt.Errorf("SSID must be valid UTF-8")
+	}
+
+	return nil
+}
+
+func validatePassword(password string) error {
+	if len(password) == 0 {
+		return fmt.Errorf("password must be non-empty")
+	}
+
+	if len(password) > MaxPasswordLen {
+		return fmt.Errorf("password must have at most %d bytes (got %d)",
+			MaxPasswordLen, len(password))
+	}
+
+	if !utf8.ValidString(password) {
+		return fmt.Errorf("password must be valid UTF-8")
 	}
 
 	return nil
@@ -0,0 +1,166 @@
+package qrcode
+
+import (
+	"encoding/base64"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/brunoga/unitybridge/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		countryCode string
+		ssID        string
+		password    string
+		bssID       string
+	}{
+		{"short country code", "C", "ssid", "password", ""},
+		{"non letter country code", "C1", "ssid", "password", ""},
+		{"empty ssid", "CN", " ", "password", ""},
+		{"long ssid", "CN", strings.Repeat("s", MaxSsIDLen+1), "password", ""},
+		{"invalid utf-8 ssid", "CN", "ss\xffid", "password", ""},
+		{"empty password", "CN", "ssid", "", ""},
+		{"long password", "CN", "ssid", strings.Repeat("p", MaxPasswordLen+1), ""},
+		{"long non-ascii password", "CN", "ssid", strings.Repeat("ü", 16), ""},
+		{"invalid bssid", "CN", "ssid", "password", "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(1234, tt.countryCode, tt.ssID, tt.password, tt.bssID)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewFromMessage_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		ssID     string
+		password string
+		bssID    string
+	}{
+		{"ascii", "Discworld", "zwergschnauzer", ""},
+		{"non-ascii", "Café Wi-Fi ☕", "contraseña", ""},
+		{"max lengths", strings.Repeat("s", MaxSsIDLen),
+			strings.Repeat("p", MaxPasswordLen), ""},
+		{"bssid", "Discworld", "zwergschnauzer", "00:11:22:aa:bb:cc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := New(0x0123456789abcdef, "CN", tt.ssID, tt.password,
+				tt.bssID)
+			assert.NoError(t, err)
+
+			parsed, err := NewFromMessage(q.Message())
+			assert.NoError(t, err)
+			assert.Equal(t, q, parsed)
+		})
+	}
+}
+
+func TestNewFromMessage_Malformed(t *testing.T) {
+	q, err := New(1234, "CN", "Discworld", "zwergschnauzer", "")
+	assert.NoError(t, err)
+
+	data, err := base64.StdEncoding.DecodeString(q.Message())
+	assert.NoError(t, err)
+
+	encode := func(data []byte) string {
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	reencode := func(mutate func(plain []byte) []byte) string {
+		plain := append([]byte(nil), data...)
+		support.SimpleEncryptDecrypt(plain)
+		plain = mutate(plain)
+		support.SimpleEncryptDecrypt(plain)
+		return encode(plain)
+	}
+
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{"not base64", "not base64!"},
+		{"empty", ""},
+		{"truncated header", encode(data[:5])},
+		{"truncated data", encode(data[:len(data)-1])},
+		{"trailing data", encode(append(append([]byte(nil), data...), 0))},
+		{"unsupported version", reencode(func(plain []byte) []byte {
+			plain[1] |= 0x10
+			return plain
+		})},
+		{"missing bssid", reencode(func(plain []byte) []byte {
+			plain[1] |= 0x08
+			return plain
+		})},
+		{"oversized ssid length", reencode(func(plain []byte) []byte {
+			plain[0] |= 0x3f
+			return plain
+		})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				_, err := NewFromMessage(tt.message)
+				assert.Error(t, err)
+			})
+		})
+	}
+}
+
+func TestCompactText_Polarity(t *testing.T) {
+	q, err := New(1234, "CN", "Discworld", "zwergschnauzer", "")
+	assert.NoError(t, err)
+
+	full, err := q.Text()
+	assert.NoError(t, err)
+
+	dark, err := q.CompactText(PolarityDarkBackground)
+	assert.NoError(t, err)
+
+	light, err := q.CompactText(PolarityLightBackground)
+	assert.NoError(t, err)
+
+	fullLines := strings.Count(full, "\n")
+	assert.Equal(t, (fullLines+1)/2, strings.Count(dark, "\n"))
+	assert.Equal(t, (fullLines+1)/2, strings.Count(light, "\n"))
+
+	// The quiet zone is light so it is drawn only for dark backgrounds.
+	assert.True(t, strings.HasPrefix(dark, "█"))
+	assert.True(t, strings.HasPrefix(light, " "))
+}
+
+func TestWriteSVG(t *testing.T) {
+	q, err := New(1234, "CN", "Discworld", "zwergschnauzer", "")
+	assert.NoError(t, err)
+
+	var sb strings.Builder
+	err = q.WriteSVG(&sb, 256)
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(sb.String(), "<svg"))
+	assert.Contains(t, sb.String(), `width="256" height="256"`)
+}
+
+func TestNewFromImage_RoundTrip(t *testing.T) {
+	q, err := New(0x0123456789abcdef, "CN", "Café Wi-Fi ☕", "zwergschnauzer",
+		"00:11:22:aa:bb:cc")
+	assert.NoError(t, err)
+
+	img, err := q.Image(256)
+	assert.NoError(t, err)
+
+	parsed, err := NewFromImage(img)
+	assert.NoError(t, err)
+	assert.Equal(t, q, parsed)
+}
+
+func TestNewFromImage_NoQRCode(t *testing.T) {
+	_, err := NewFromImage(image.NewGray(image.Rect(0, 0, 64, 64)))
+	assert.Error(t, err)
+}
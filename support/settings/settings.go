@@ -0,0 +1,284 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/brunoga/unitybridge"
+	"github.com/brunoga/unitybridge/unity/key"
+)
+
+// snapshotVersion is the current version of the snapshot file format.
+const snapshotVersion = 1
+
+// Setting is the value of a single readable and writable key.
+type Setting struct {
+	Key   *key.Key        `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The setting key is resolved to
+// the registered key with the same name or sub-type.
+func (s *Setting) UnmarshalJSON(data []byte) error {
+	var setting struct {
+		Key   key.Ref         `json:"key"`
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(data, &setting); err != nil {
+		return err
+	}
+
+	s.Key = setting.Key.Key
+	s.Value = setting.Value
+
+	return nil
+}
+
+// Snapshot is a point in time capture of the settings of a robot. It can be
+// restored to the same or to another robot.
+type Snapshot struct {
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Settings  []*Setting `json:"settings"`
+}
+
+// Change is a difference between a setting in a Snapshot and the current
+// value of the same setting in a robot.
+type Change struct {
+	Key     *key.Key
+	Current json.RawMessage // Nil if the current value could not be read.
+	Target  json.RawMessage
+}
+
+// String returns a string representation of the Change.
+func (c *Change) String() string {
+	current := "<unknown>"
+	if c.Current != nil {
+		current = string(c.Current)
+	}
+
+	return fmt.Sprintf("%s: %s -> %s", c.Key, current, c.Target)
+}
+
+// gameKeys are readable and writable keys that hold the state of a game or
+// match instead of robot settings. Restoring them would, for example, revive
+// a robot or refill its bullets.
+var gameKeys = map[*key.Key]bool{
+	key.KeyRobomasterSystemUnderAbilitiesAttack: true,
+	key.KeyRobomasterSystemGameRoleConfig:       true,
+	key.KeyRobomasterSystemGameColorConfig:      true,
+	key.KeyRobomasterSystemCurrentHP:            true,
+	key.KeyRobomasterSystemTotalHP:              true,
+	key.KeyRobomasterSystemCurrentBullets:       true,
+	key.KeyRobomasterSystemTotalBullets:         true,
+}
+
+// Keys returns all keys that are included in snapshots (keys that are both
+// readable and writable, except for the ones holding game state) sorted by
+// sub-type.
+func Keys() []*key.Key {
+	var keys []*key.Key
+	for _, k := range key.ByAccessType(key.AccessTypeRead |
+		key.AccessTypeWrite) {
+		if !gameKeys[k] {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Capture reads all the settings (see Keys()) from the robot connected to
+// the given UnityBridge. Settings that can not be read are skipped and
+// reported in the returned error, which is returned together with the
+// (partial) Snapshot.
+func Capture(ub unitybridge.UnityBridge) (*Snapshot, error) {
+	s := &Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: time.Now(),
+	}
+
+	var err error
+	for _, k := range Keys() {
+		value, readErr := read(ub, k)
+		if readErr != nil {
+			err = errors.Join(err, readErr)
+			continue
+		}
+
+		s.Settings = append(s.Settings, &Setting{
+			Key:   k,
+			Value: value,
+		})
+	}
+
+	return s, err
+}
+
+// Diff returns the settings in the given Snapshot that are different from the
+// current settings of the robot connected to the given UnityBridge. Settings
+// that can not be read are considered different.
+func Diff(ub unitybridge.UnityBridge, s *Snapshot) ([]*Change, error) {
+	var changes []*Change
+
+	for _, setting := range s.Settings {
+		target, err := normalize(setting.Key, setting.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		current, _ := read(ub, setting.Key)
+
+		if current == nil || !bytes.Equal(current, target) {
+			changes = append(changes, &Change{
+				Key:     setting.Key,
+				Current: current,
+				Target:  target,
+			})
+		}
+	}
+
+	return changes, nil
+}
+
+// Restore sets all settings in the given Snapshot that are different from the
+// current settings of the robot connected to the given UnityBridge. It
+// returns the changes that were applied. Settings that fail to be set are
+// reported in the returned error.
+func Restore(ub unitybridge.UnityBridge, s *Snapshot) ([]*Change, error) {
+	changes, err := Diff(ub, s)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []*Change
+	for _, change := range changes {
+		value, setErr := decode(change.Key, change.Target)
+		if setErr == nil {
+			setErr = ub.SetKeyValueSync(change.Key, value)
+		}
+
+		if setErr != nil {
+			err = errors.Join(err, fmt.Errorf("error setting key %s: %w",
+				change.Key, setErr))
+			continue
+		}
+
+		applied = append(applied, change)
+	}
+
+	return applied, err
+}
+
+// Load loads a Snapshot from the file at the given path.
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{}
+	if err = json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("error parsing snapshot %s: %w", path, err)
+	}
+
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d in %s",
+			s.Version, path)
+	}
+
+	for _, setting := range s.Settings {
+		if setting.Key == nil {
+			return nil, fmt.Errorf("setting without key in %s", path)
+		}
+
+		if _, err = normalize(setting.Key, setting.Value); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Save saves the Snapshot to the file at the given path.
+func (s *Snapshot) Save(path string) error {
+	sort.Slice(s.Settings, func(i, j int) bool {
+		return s.Settings[i].Key.SubType() < s.Settings[j].Key.SubType()
+	})
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// read returns the normalized JSON value of the given key.
+func read(ub unitybridge.UnityBridge, k *key.Key) (json.RawMessage, error) {
+	r, err := ub.GetKeyValueSync(k, false)
+	if err != nil {
+		return nil, fmt.Errorf("error reading key %s: %w", k, err)
+	}
+
+	if !r.Succeeded() {
+		return nil, fmt.Errorf("error reading key %s: %s", k, r.ErrorDesc())
+	}
+
+	if raw, ok := r.Value().(json.RawMessage); ok {
+		return normalize(k, raw)
+	}
+
+	return json.Marshal(r.Value())
+}
+
+// decode returns the given JSON value as a value that can be set for the
+// given key. Values for keys with an unknown value type are kept as raw JSON.
+func decode(k *key.Key, data json.RawMessage) (any, error) {
+	if k.ValueTypeName() == "" {
+		if !json.Valid(data) {
+			return nil, fmt.Errorf("invalid value for key %s: %s", k, data)
+		}
+
+		return data, nil
+	}
+
+	value := k.ResultValue()
+	if err := json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("invalid value for key %s: %w", k, err)
+	}
+
+	return value, nil
+}
+
+// normalize returns the given JSON value re-encoded (through the key value
+// type, if known) so values can be compared byte by byte. It also validates
+// the value.
+func normalize(k *key.Key, data json.RawMessage) (json.RawMessage, error) {
+	value, err := decode(k, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw, ok := value.(json.RawMessage); ok {
+		var b bytes.Buffer
+		if err = json.Compact(&b, raw); err != nil {
+			return nil, err
+		}
+
+		return b.Bytes(), nil
+	}
+
+	return json.Marshal(value)
+}
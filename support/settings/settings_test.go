@@ -0,0 +1,108 @@
+package settings
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/brunoga/unitybridge/internal/fakebridge"
+	"github.com/brunoga/unitybridge/unity/key"
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+var keyTestSettingsTyped = func() *key.Key {
+	k, err := key.Register("KeyTestSettingsTyped", 0xfe000010,
+		key.AccessTypeRead|key.AccessTypeWrite, &value.Uint64{})
+	if err != nil {
+		panic(err)
+	}
+
+	return k
+}()
+
+func TestKeys(t *testing.T) {
+	keys := Keys()
+	assert.Contains(t, keys, key.KeyGimbalWorkMode)
+	assert.Contains(t, keys, keyTestSettingsTyped)
+
+	for k := range gameKeys {
+		assert.NotContains(t, keys, k)
+	}
+}
+
+func TestCaptureRestore(t *testing.T) {
+	untyped := key.KeyCameraDigitalZoomFactor
+
+	ub := fakebridge.New()
+	ub.SetValue(keyTestSettingsTyped, &value.Uint64{Value: 1})
+	ub.SetValue(untyped, json.RawMessage(`{ "value": 2 }`))
+
+	s, err := Capture(ub)
+	assert.Error(t, err) // Other keys have no values.
+	assert.Len(t, s.Settings, 2)
+
+	path := filepath.Join(t.TempDir(), "settings", "snapshot.json")
+	assert.NoError(t, s.Save(path))
+
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotVersion, loaded.Version)
+	assert.Len(t, loaded.Settings, 2)
+	for _, setting := range loaded.Settings {
+		k, err := key.FromSubType(setting.Key.SubType())
+		assert.NoError(t, err)
+		assert.Same(t, k, setting.Key)
+	}
+
+	changes, err := Diff(ub, loaded)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	ub.SetValue(keyTestSettingsTyped, &value.Uint64{Value: 3})
+	ub.SetValue(untyped, json.RawMessage(`{"value":4}`))
+
+	changes, err = Diff(ub, loaded)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 2)
+
+	applied, err := Restore(ub, loaded)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	assert.Equal(t, &value.Uint64{Value: 1},
+		ub.Value(keyTestSettingsTyped))
+	assert.JSONEq(t, `{"value":2}`,
+		string(ub.Value(untyped).(json.RawMessage)))
+
+	changes, err = Diff(ub, loaded)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	s := &Snapshot{
+		Version: snapshotVersion + 1,
+	}
+
+	path := filepath.Join(dir, "version.json")
+	assert.NoError(t, s.Save(path))
+
+	_, err := Load(path)
+	assert.Error(t, err)
+
+	s = &Snapshot{
+		Version: snapshotVersion,
+		Settings: []*Setting{
+			{keyTestSettingsTyped, json.RawMessage(`{"value":"string"}`)},
+		},
+	}
+
+	path = filepath.Join(dir, "value.json")
+	assert.NoError(t, s.Save(path))
+
+	_, err = Load(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package event
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalText implements encoding.TextMarshaler. Types are marshaled as their
+// names. Unknown types are marshaled as their numeric value.
+func (t Type) MarshalText() ([]byte, error) {
+	s := t.String()
+	if s == "Unknown" {
+		s = strconv.FormatUint(uint64(t), 10)
+	}
+
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts type names
+// (for example, "StartListening") and numeric values.
+func (t *Type) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	for _, typ := range AllTypes() {
+		if typ.String() == s {
+			*t = typ
+			return nil
+		}
+	}
+
+	n, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return fmt.Errorf("invalid event type: %q", s)
+	}
+
+	*t = Type(n)
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestType_TextRoundTrip(t *testing.T) {
+	for _, typ := range AllTypes() {
+		text, err := typ.MarshalText()
+		assert.NoError(t, err)
+
+		var parsed Type
+		assert.NoError(t, parsed.UnmarshalText(text))
+		assert.Equal(t, typ, parsed)
+	}
+}
+
+func TestType_UnmarshalText_Numeric(t *testing.T) {
+	var typ Type
+	assert.NoError(t, typ.UnmarshalText([]byte("0")))
+	assert.Equal(t, TypeSetValue, typ)
+
+	assert.Error(t, typ.UnmarshalText([]byte("NotAType")))
+}
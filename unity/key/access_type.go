@@ -1,5 +1,11 @@
 package key
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // AccessType is the type of access allowed for a specific key.
 type AccessType int32
 
@@ -9,3 +15,74 @@ const (
 	AccessTypeWrite
 	AccessTypeAction
 )
+
+// String returns the string representation of the AccessType (for example,
+// "Read|Write").
+func (a AccessType) String() string {
+	if a == AccessTypeNone {
+		return "None"
+	}
+
+	var parts []string
+	for _, accessType := range []AccessType{AccessTypeRead, AccessTypeWrite,
+		AccessTypeAction} {
+		if a&accessType != 0 {
+			parts = append(parts, accessTypeName(accessType))
+			a &^= accessType
+		}
+	}
+
+	if a != 0 {
+		parts = append(parts, fmt.Sprintf("0x%x", int32(a)))
+	}
+
+	return strings.Join(parts, "|")
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a AccessType) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the format
+// returned by String() (for example, "Read|Write") and numeric values.
+func (a *AccessType) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+
+	if n, err := strconv.ParseInt(s, 0, 32); err == nil {
+		*a = AccessType(n)
+		return nil
+	}
+
+	var accessType AccessType
+	for _, part := range strings.Split(s, "|") {
+		switch strings.TrimSpace(part) {
+		case "None":
+		case "Read":
+			accessType |= AccessTypeRead
+		case "Write":
+			accessType |= AccessTypeWrite
+		case "Action":
+			accessType |= AccessTypeAction
+		default:
+			return fmt.Errorf("invalid access type: %q", s)
+		}
+	}
+
+	*a = accessType
+
+	return nil
+}
+
+func accessTypeName(accessType AccessType) string {
+	switch accessType {
+	case AccessTypeRead:
+		return "Read"
+	case AccessTypeWrite:
+		return "Write"
+	case AccessTypeAction:
+		return "Action"
+	}
+
+	return ""
+}
@@ -0,0 +1,210 @@
+// Command gen generates the Unity Bridge key table from its spec file. It is
+// run through go generate in the unity/key package.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	specPath = flag.String("spec", "keys.spec", "Path to the key spec file.")
+	outPath  = flag.String("out", "keys_gen.go", "Path to the generated file.")
+)
+
+var (
+	nameRegexp      = regexp.MustCompile(`^Key[A-Z][A-Za-z0-9]*$`)
+	valueTypeRegexp = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*(\[[a-z][a-z0-9]*\])?$`)
+
+	accessTypes = map[string]bool{
+		"None":   true,
+		"Read":   true,
+		"Write":  true,
+		"Action": true,
+	}
+)
+
+// keySpec is the specification for a single key.
+type keySpec struct {
+	name        string
+	subType     uint32
+	accessTypes []string
+	valueType   string // Empty if unknown.
+}
+
+func main() {
+	flag.Parse()
+
+	f, err := os.Open(*specPath)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	groups, err := parseSpec(f)
+	if err != nil {
+		panic(fmt.Sprintf("%s: %s", *specPath, err))
+	}
+
+	data, err := generate(groups, *specPath)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = os.WriteFile(*outPath, data, 0644); err != nil {
+		panic(err)
+	}
+}
+
+// parseSpec parses and validates the key spec read from the given reader.
+// It returns the keys in the spec grouped as in the spec file.
+func parseSpec(r io.Reader) ([][]keySpec, error) {
+	var groups [][]keySpec
+	var group []keySpec
+
+	names := make(map[string]int)
+	subTypes := make(map[uint32]int)
+
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if line == "" {
+			if len(group) > 0 {
+				groups = append(groups, group)
+				group = nil
+			}
+			continue
+		}
+
+		ks, err := parseKeySpec(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		if other, ok := names[ks.name]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key name %s (also "+
+				"in line %d)", lineNum, ks.name, other)
+		}
+		names[ks.name] = lineNum
+
+		if other, ok := subTypes[ks.subType]; ok {
+			return nil, fmt.Errorf("line %d: duplicate sub-type %d (also in "+
+				"line %d)", lineNum, ks.subType, other)
+		}
+		subTypes[ks.subType] = lineNum
+
+		group = append(group, ks)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(group) > 0 {
+		groups = append(groups, group)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no keys found")
+	}
+
+	return groups, nil
+}
+
+func parseKeySpec(line string) (keySpec, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return keySpec{}, fmt.Errorf("expected 4 fields, got %d",
+			len(fields))
+	}
+
+	name := fields[0]
+	if !nameRegexp.MatchString(name) {
+		return keySpec{}, fmt.Errorf("invalid key name %q", name)
+	}
+
+	subType, err := strconv.ParseUint(fields[1], 10, 32)
+	if err != nil {
+		return keySpec{}, fmt.Errorf("invalid sub-type %q for key %s",
+			fields[1], name)
+	}
+
+	keyAccessTypes := strings.Split(fields[2], "|")
+	for _, accessType := range keyAccessTypes {
+		if !accessTypes[accessType] {
+			return keySpec{}, fmt.Errorf("invalid access type %q for key %s",
+				accessType, name)
+		}
+	}
+
+	valueType := fields[3]
+	if valueType == "-" {
+		valueType = ""
+	} else if !valueTypeRegexp.MatchString(valueType) {
+		return keySpec{}, fmt.Errorf("invalid value type %q for key %s",
+			valueType, name)
+	}
+
+	return keySpec{
+		name:        name,
+		subType:     uint32(subType),
+		accessTypes: keyAccessTypes,
+		valueType:   valueType,
+	}, nil
+}
+
+// generate returns the formatted Go source for the given key groups.
+func generate(groups [][]keySpec, specPath string) ([]byte, error) {
+	var b bytes.Buffer
+
+	numKeys := 0
+	for _, group := range groups {
+		numKeys += len(group)
+	}
+
+	fmt.Fprintf(&b, "// Code generated by internal/gen from %s; DO NOT EDIT.\n\n",
+		specPath)
+	fmt.Fprintf(&b, "package key\n\n")
+	fmt.Fprintf(&b, "import \"github.com/brunoga/unitybridge/unity/result/value\"\n\n")
+	fmt.Fprintf(&b, "const numKeys = %d\n\n", numKeys)
+	fmt.Fprintf(&b, "var (\n")
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintf(&b, "\n")
+		}
+
+		for _, ks := range group {
+			accessTypes := make([]string, len(ks.accessTypes))
+			for j, accessType := range ks.accessTypes {
+				accessTypes[j] = "AccessType" + accessType
+			}
+
+			resultValue := "nil"
+			if ks.valueType != "" {
+				resultValue = "&value." + ks.valueType + "{}"
+			}
+
+			fmt.Fprintf(&b, "\t%s = newKey(%q, %d, %s, %s)\n", ks.name,
+				ks.name, ks.subType, strings.Join(accessTypes, "|"),
+				resultValue)
+		}
+	}
+
+	fmt.Fprintf(&b, ")\n")
+
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpec_Valid(t *testing.T) {
+	groups, err := parseSpec(strings.NewReader(`
+# Comment.
+KeyA 1 Read         Bool
+KeyB 2 Read|Write   -
+
+KeyC 16777217 Action List[uint16]
+`))
+	assert.NoError(t, err)
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[0], 2)
+	assert.Len(t, groups[1], 1)
+
+	assert.Equal(t, keySpec{"KeyB", 2, []string{"Read", "Write"}, ""},
+		groups[0][1])
+	assert.Equal(t, keySpec{"KeyC", 16777217, []string{"Action"},
+		"List[uint16]"}, groups[1][0])
+
+	data, err := generate(groups, "test.spec")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "const numKeys = 3")
+	assert.Contains(t, string(data),
+		`KeyB = newKey("KeyB", 2, AccessTypeRead|AccessTypeWrite, nil)`)
+	assert.Contains(t, string(data),
+		`KeyC = newKey("KeyC", 16777217, AccessTypeAction, &value.List[uint16]{})`)
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{"empty", "# Nothing here.\n"},
+		{"missing field", "KeyA 1 Read\n"},
+		{"invalid name", "A 1 Read -\n"},
+		{"invalid sub-type", "KeyA x Read -\n"},
+		{"sub-type overflow", "KeyA 4294967296 Read -\n"},
+		{"invalid access type", "KeyA 1 Read|Execute -\n"},
+		{"invalid value type", "KeyA 1 Read value.Bool\n"},
+		{"duplicate name", "KeyA 1 Read -\nKeyA 2 Read -\n"},
+		{"duplicate sub-type", "KeyA 1 Read -\nKeyB 1 Read -\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSpec(strings.NewReader(tt.spec))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,376 @@
+# Unity Bridge keys. Each line describes one key:
+#
+#   <name> <sub-type> <access type> <value type>
+#
+# The access type is a "|" separated list of Read, Write and Action (or None).
+# The value type is the name of a type in the unity/result/value package or "-"
+# if it is still unknown. Blank lines separate key groups.
+#
+# After changing this file, run "go generate" in this directory.
+
+KeyProductTest 1 Write -
+KeyProductType 2 Read  -
+
+KeyCameraConnection                            16777217 Read       Bool
+KeyCameraFirmwareVersion                       16777218 Read       -
+KeyCameraStartShootPhoto                       16777219 Action     -
+KeyCameraIsShootingPhoto                       16777220 Read       -
+KeyCameraPhotoSize                             16777221 Read|Write -
+KeyCameraStartRecordVideo                      16777222 Action     -
+KeyCameraStopRecordVideo                       16777223 Action     -
+KeyCameraIsRecording                           16777224 Read       -
+KeyCameraCurrentRecordingTimeInSeconds         16777225 Read       -
+KeyCameraVideoFormat                           16777226 Read|Write -
+KeyCameraMode                                  16777227 Read|Write -
+KeyCameraDigitalZoomFactor                     16777228 Read|Write -
+KeyCameraAntiFlicker                           16777229 Read|Write -
+KeyCameraSwitch                                16777230 Action     -
+KeyCameraCurrentCameraIndex                    16777231 Read       -
+KeyCameraHasMainCamera                         16777232 Read       -
+KeyCameraHasSecondaryCamera                    16777233 Read       -
+KeyCameraFormatSDCard                          16777234 Action     -
+KeyCameraSDCardIsFormatting                    16777235 Read       -
+KeyCameraSDCardIsFull                          16777236 Read       -
+KeyCameraSDCardHasError                        16777237 Read       -
+KeyCameraSDCardIsInserted                      16777238 Read       -
+KeyCameraSDCardTotalSpaceInMB                  16777239 Read       -
+KeyCameraSDCardRemaingSpaceInMB                16777240 Read       -
+KeyCameraSDCardAvailablePhotoCount             16777241 Read       -
+KeyCameraSDCardAvailableRecordingTimeInSeconds 16777242 Read       -
+KeyCameraIsTimeSynced                          16777243 Read       -
+KeyCameraDate                                  16777244 Read|Write -
+KeyCameraVideoTransRate                        16777245 Write      Float64
+KeyCameraRequestIFrame                         16777246 Action     -
+KeyCameraAntiLarsenAlgorithmEnable             16777247 Write      -
+
+KeyMainControllerConnection             33554433 Read       Bool
+KeyMainControllerFirmwareVersion        33554434 Read       -
+KeyMainControllerLoaderVersion          33554435 Read       -
+KeyMainControllerVirtualStick           33554436 Action     Uint64
+KeyMainControllerVirtualStickEnabled    33554437 Read|Write Bool
+KeyMainControllerChassisSpeedMode       33554438 Write      -
+KeyMainControllerChassisFollowMode      33554439 Write      -
+KeyMainControllerChassisCarControlMode  33554440 Write      Uint64
+KeyMainControllerRecordState            33554441 Read|Write -
+KeyMainControllerGetRecordSetting       33554442 Read|Write -
+KeyMainControllerSetRecordSetting       33554443 Read       -
+KeyMainControllerPlayRecordAttr         33554444 Read|Write -
+KeyMainControllerGetPlayRecordSetting   33554445 Read       -
+KeyMainControllerSetPlayRecordSetting   33554446 Read|Write -
+KeyMainControllerMaxSpeedForward        33554447 Read|Write -
+KeyMainControllerMaxSpeedBackward       33554448 Read|Write -
+KeyMainControllerMaxSpeedLateral        33554449 Read|Write -
+KeyMainControllerSlopeY                 33554450 Read|Write -
+KeyMainControllerSlopeX                 33554451 Read|Write -
+KeyMainControllerSlopeBreakY            33554452 Read|Write -
+KeyMainControllerSlopeBreakX            33554453 Read|Write -
+KeyMainControllerMaxSpeedForwardConfig  33554454 Read|Write -
+KeyMainControllerMaxSpeedBackwardConfig 33554455 Read|Write -
+KeyMainControllerMaxSpeedLateralConfig  33554456 Read|Write -
+KeyMainControllerSlopSpeedYConfig       33554457 Read|Write -
+KeyMainControllerSlopSpeedXConfig       33554458 Read|Write -
+KeyMainControllerSlopBreakYConfig       33554459 Read|Write -
+KeyMainControllerSlopBreakXConfig       33554460 Read|Write -
+KeyMainControllerChassisPosition        33554461 Action     ChassisPosition
+KeyMainControllerWheelSpeed             33554462 Write      WheelSpeed
+KeyMainControllerArmServoID             33554477 Read|Write -
+KeyMainControllerServoAddressing        33554478 Action     -
+KeyMainControllerGetLinkAck             83886091 Read       -
+
+KeyRobomasterMainControllerEscEncodingStatus        33554463 Read   -
+KeyRobomasterMainControllerEscEncodeFlag            33554464 Write  -
+KeyRobomasterMainControllerStartIMUCalibration      33554465 Action -
+KeyRobomasterMainControllerIMUCalibrationState      33554466 Read   -
+KeyRobomasterMainControllerIMUCalibrationCurrSide   33554467 Read   -
+KeyRobomasterMainControllerIMUCalibrationProgress   33554468 Read   -
+KeyRobomasterMainControllerIMUCalibrationFailCode   33554469 Read   -
+KeyRobomasterMainControllerIMUCalibrationFinishFlag 33554470 Read   -
+KeyRobomasterMainControllerStopIMUCalibration       33554471 Action -
+KeyRobomasterMainControllerRelativePosition         33554476 Read   RelativePosition
+
+KeyRobomasterChassisMode              33554472 Read   -
+KeyRobomasterChassisSpeed             33554473 Read   ChassisSpeed
+KeyRobomasterOpenChassisSpeedUpdates  33554474 Action Void
+KeyRobomasterCloseChassisSpeedUpdates 33554475 Action Void
+
+KeyRobomasterSystemConnection                       83886081 Read       Bool
+KeyRobomasterSystemFirmwareVersion                  83886082 Read       -
+KeyRobomasterSystemCANFirmwareVersion               83886083 Read       -
+KeyRobomasterSystemScratchFirmwareVersion           83886084 Read       -
+KeyRobomasterSystemSerialNumber                     83886085 Read       -
+KeyRobomasterSystemAbilitiesAttack                  83886086 Action     -
+KeyRobomasterSystemUnderAbilitiesAttack             83886087 Read|Write ArmorHit
+KeyRobomasterSystemKill                             83886088 Action     Void
+KeyRobomasterSystemRevive                           83886089 Action     Void
+KeyRobomasterSystemGet1860LinkAck                   83886090 Read       -
+KeyRobomasterSystemGameRoleConfig                   83886093 Read|Write Uint64
+KeyRobomasterSystemGameColorConfig                  83886094 Read|Write Uint64
+KeyRobomasterSystemGameStart                        83886095 Action     Void
+KeyRobomasterSystemGameEnd                          83886096 Action     Void
+KeyRobomasterSystemDebugLog                         83886097 Read       -
+KeyRobomasterSystemSoundEnabled                     83886098 Read|Write -
+KeyRobomasterSystemLeftHeadlightBrightness          83886099 Read|Write Uint64
+KeyRobomasterSystemRightHeadlightBrightness         83886100 Read|Write Uint64
+KeyRobomasterSystemLEDColor                         83886101 Write      LEDColor
+KeyRobomasterSystemUploadScratch                    83886102 Write      -
+KeyRobomasterSystemUploadScratchByFTP               83886103 Write      -
+KeyRobomasterSystemUninstallScratchSkill            83886104 Action     -
+KeyRobomasterSystemInstallScratchSkill              83886105 Action     -
+KeyRobomasterSystemInquiryDspMd5                    83886106 Write      -
+KeyRobomasterSystemInquiryDspMd5Ack                 83886107 Write      -
+KeyRobomasterSystemInquiryDspResourceMd5            83886108 Write      -
+KeyRobomasterSystemInquiryDspResourceMd5Ack         83886109 Write      -
+KeyRobomasterSystemLaunchSinglePlayerCustomSkill    83886110 Action     -
+KeyRobomasterSystemStopSinglePlayerCustomSkill      83886111 Action     -
+KeyRobomasterSystemControlScratch                   83886112 Action     -
+KeyRobomasterSystemScratchState                     83886113 Read       -
+KeyRobomasterSystemScratchCallback                  83886114 Read       -
+KeyRobomasterSystemForesightPosition                83886115 Read|Write -
+KeyRobomasterSystemPullLogFiles                     83886116 Read       -
+KeyRobomasterSystemCurrentHP                        83886117 Read|Write Uint64
+KeyRobomasterSystemTotalHP                          83886118 Read|Write Uint64
+KeyRobomasterSystemCurrentBullets                   83886119 Read|Write Uint64
+KeyRobomasterSystemTotalBullets                     83886120 Read|Write Uint64
+KeyRobomasterSystemEquipments                       83886121 Read       List[uint8]
+KeyRobomasterSystemBuffs                            83886122 Read       List[uint8]
+KeyRobomasterSystemSkillStatus                      83886123 Read       -
+KeyRobomasterSystemGunCoolDown                      83886124 Read       Bool
+KeyRobomasterSystemGameConfigList                   83886125 Write      -
+KeyRobomasterSystemCarAndSkillID                    83886126 Write      -
+KeyRobomasterSystemAppStatus                        83886127 Write      -
+KeyRobomasterSystemLaunchMultiPlayerSkill           83886128 Action     -
+KeyRobomasterSystemStopMultiPlayerSkill             83886129 Action     -
+KeyRobomasterSystemConfigSkillTable                 83886130 Write      -
+KeyRobomasterSystemWorkingDevices                   83886131 Read       List[uint16]
+KeyRobomasterSystemExceptions                       83886132 Read       -
+KeyRobomasterSystemTaskStatus                       83886133 Read       TaskStatus
+KeyRobomasterSystemReturnEnabled                    83886134 Read|Write -
+KeyRobomasterSystemSafeMode                         83886135 Read|Write -
+KeyRobomasterSystemScratchExecuteState              83886136 Read       -
+KeyRobomasterSystemAttitudeInfo                     83886137 Read       AttitudeInfo
+KeyRobomasterSystemSightBeadPosition                83886138 Read|Write -
+KeyRobomasterSystemSpeakerLanguage                  83886139 Read|Write -
+KeyRobomasterSystemSpeakerVolumn                    83886140 Read|Write -
+KeyRobomasterSystemChassisSpeedLevel                83886141 Read|Write -
+KeyRobomasterSystemIsEncryptedFirmware              83886142 Read       -
+KeyRobomasterSystemScratchErrorInfo                 83886143 Read       -
+KeyRobomasterSystemScratchOutputInfo                83886144 Read       -
+KeyRobomasterSystemBarrelCoolDown                   83886145 Action     Void
+KeyRobomasterSystemResetBarrelOverheat              83886146 Action     Void
+KeyRobomasterSystemMobileAccelerInfo                83886147 Write      -
+KeyRobomasterSystemMobileGyroAttitudeAngleInfo      83886148 Write      -
+KeyRobomasterSystemMobileGyroRotationRateInfo       83886149 Write      -
+KeyRobomasterSystemEnableAcceleratorSubscribe       83886150 Read|Write -
+KeyRobomasterSystemEnableGyroRotationRateSubscribe  83886151 Read|Write -
+KeyRobomasterSystemEnableGyroAttitudeAngleSubscribe 83886152 Read|Write -
+KeyRobomasterSystemDeactivate                       83886153 Action     -
+KeyRobomasterSystemFunctionEnable                   83886154 Action     FunctionEnable
+KeyRobomasterSystemIsGameRunning                    83886155 Read       Bool
+KeyRobomasterSystemIsActivated                      83886156 Read       -
+KeyRobomasterSystemLowPowerConsumption              83886157 Read|Write -
+KeyRobomasterSystemEnterLowPowerConsumption         83886158 Action     -
+KeyRobomasterSystemExitLowPowerConsumption          83886159 Action     -
+KeyRobomasterSystemIsLowPowerConsumption            83886160 Read       -
+KeyRobomasterSystemPushFile                         83886161 Action     -
+KeyRobomasterSystemPlaySound                        83886162 Action     -
+KeyRobomasterSystemPlaySoundStatus                  83886163 Read       -
+KeyRobomasterSystemCustomUIAttribute                83886164 Read       -
+KeyRobomasterSystemCustomUIFunctionEvent            83886165 Action     -
+KeyRobomasterSystemTotalMileage                     83886166 Read       -
+KeyRobomasterSystemTotalDrivingTime                 83886167 Read       -
+KeyRobomasterSystemSetPlayMode                      83886168 Write      -
+KeyRobomasterSystemCustomSkillInfo                  83886169 Read       -
+KeyRobomasterSystemAddressing                       83886170 Action     -
+KeyRobomasterSystemLEDLightEffect                   83886171 Action     LEDLightEffect
+KeyRobomasterSystemOpenImageTransmission            83886172 Action     -
+KeyRobomasterSystemCloseImageTransmission           83886173 Action     -
+
+KeyRobomasterWaterGunFirmwareVersion       167772161 Read   -
+KeyRobomasterWaterGunWaterGunFire          167772162 Action Void
+KeyRobomasterWaterGunWaterGunFireWithTimes 167772163 Action Uint64
+KeyRobomasterWaterGunShootSpeed            167772164 Read   Uint64
+KeyRobomasterWaterGunShootFrequency        167772165 Read   Uint64
+
+KeyRobomasterInfraredGunConnection      301989889 Read   Bool
+KeyRobomasterInfraredGunFirmwareVersion 301989890 Read   -
+KeyRobomasterInfraredGunInfraredGunFire 301989891 Action Void
+KeyRobomasterInfraredGunShootFrequency  301989892 Read   Uint64
+
+KeyRobomasterBatteryFirmwareVersion 218103809 Read   -
+KeyRobomasterBatteryPowerPercent    218103810 Read   Uint64
+KeyRobomasterBatteryVoltage         218103811 Read   -
+KeyRobomasterBatteryTemperature     218103812 Read   -
+KeyRobomasterBatteryCurrent         218103813 Read   -
+KeyRobomasterBatteryShutdown        218103814 Action -
+KeyRobomasterBatteryReboot          218103815 Action -
+
+KeyRobomasterGamePadConnection                   234881025 Read       Bool
+KeyRobomasterGamePadFirmwareVersion              234881026 Read       -
+KeyRobomasterGamePadHasMouse                     234881027 Read       -
+KeyRobomasterGamePadHasKeyboard                  234881028 Read       -
+KeyRobomasterGamePadCtrlSensitivityX             234881029 Write      -
+KeyRobomasterGamePadCtrlSensitivityY             234881030 Write      -
+KeyRobomasterGamePadCtrlSensitivityYaw           234881031 Write      -
+KeyRobomasterGamePadCtrlSensitivityYawSlop       234881032 Write      -
+KeyRobomasterGamePadCtrlSensitivityYawDeadZone   234881033 Write      -
+KeyRobomasterGamePadCtrlSensitivityPitch         234881034 Write      -
+KeyRobomasterGamePadCtrlSensitivityPitchSlop     234881035 Write      -
+KeyRobomasterGamePadCtrlSensitivityPitchDeadZone 234881036 Write      -
+KeyRobomasterGamePadMouseLeftButton              234881037 Read       -
+KeyRobomasterGamePadMouseRightButton             234881038 Read       -
+KeyRobomasterGamePadC1                           234881039 Read       -
+KeyRobomasterGamePadC2                           234881040 Read       -
+KeyRobomasterGamePadFire                         234881041 Read       -
+KeyRobomasterGamePadFn                           234881042 Read       -
+KeyRobomasterGamePadNoCalibrate                  234881043 Read       -
+KeyRobomasterGamePadNotAtMiddle                  234881044 Read       -
+KeyRobomasterGamePadBatteryWarning               234881045 Read       -
+KeyRobomasterGamePadBatteryPercent               234881046 Read       -
+KeyRobomasterGamePadActivationSettings           234881047 Read|Write -
+KeyRobomasterGamePadControlEnabled               234881048 Write      -
+
+KeyRobomasterClawConnection          251658241 Read   Bool
+KeyRobomasterClawFirmwareVersion     251658242 Read   -
+KeyRobomasterClawCtrl                251658243 Action -
+KeyRobomasterClawStatus              251658244 Read   -
+KeyRobomasterClawInfoSubscribe       251658245 Read   -
+KeyRobomasterEnableClawInfoSubscribe 251658246 Action Bool
+
+KeyRobomasterArmConnection          285212673 Read       Bool
+KeyRobomasterArmCtrl                285212674 Action     -
+KeyRobomasterArmCtrlMode            285212675 Action     -
+KeyRobomasterArmCalibration         285212676 Action     -
+KeyRobomasterArmBlockedFlag         285212677 Read       -
+KeyRobomasterArmPositionSubscribe   285212678 Read       -
+KeyRobomasterArmReachLimitX         285212679 Read       -
+KeyRobomasterArmReachLimitY         285212680 Read       -
+KeyRobomasterEnableArmInfoSubscribe 285212681 Action     Bool
+KeyRobomasterArmControlMode         285212682 Read|Write -
+
+KeyRobomasterTOFConnection          318767105 Read   Bool
+KeyRobomasterTOFLEDColor            318767106 Write  -
+KeyRobomasterTOFOnlineModules       318767107 Read   -
+KeyRobomasterTOFInfoSubscribe       318767108 Read   -
+KeyRobomasterEnableTOFInfoSubscribe 318767109 Action Bool
+KeyRobomasterTOFFirmwareVersion1    318767110 Read   -
+KeyRobomasterTOFFirmwareVersion2    318767111 Read   -
+KeyRobomasterTOFFirmwareVersion3    318767112 Read   -
+KeyRobomasterTOFFirmwareVersion4    318767113 Read   -
+
+KeyRobomasterServoConnection          335544321 Read   Bool
+KeyRobomasterServoLEDColor            335544322 Write  -
+KeyRobomasterServoSpeed               335544323 Write  -
+KeyRobomasterServoOnlineModules       335544324 Read   -
+KeyRobomasterServoInfoSubscribe       335544325 Read   -
+KeyRobomasterEnableServoInfoSubscribe 335544326 Action Bool
+KeyRobomasterServoFirmwareVersion1    335544327 Read   -
+KeyRobomasterServoFirmwareVersion2    335544328 Read   -
+KeyRobomasterServoFirmwareVersion3    335544329 Read   -
+KeyRobomasterServoFirmwareVersion4    335544330 Read   -
+
+KeyRobomasterSensorAdapterConnection          352321537 Read   Bool
+KeyRobomasterSensorAdapterOnlineModules       352321538 Read   -
+KeyRobomasterSensorAdapterInfoSubscribe       352321539 Read   -
+KeyRobomasterEnableSensorAdapterInfoSubscribe 352321540 Action Bool
+KeyRobomasterSensorAdapterFirmwareVersion1    352321541 Read   -
+KeyRobomasterSensorAdapterFirmwareVersion2    352321542 Read   -
+KeyRobomasterSensorAdapterFirmwareVersion3    352321543 Read   -
+KeyRobomasterSensorAdapterFirmwareVersion4    352321544 Read   -
+KeyRobomasterSensorAdapterFirmwareVersion5    352321545 Read   -
+KeyRobomasterSensorAdapterFirmwareVersion6    352321546 Read   -
+KeyRobomasterSensorAdapterLEDColor            352321547 Write  -
+
+KeyRemoteControllerConnection 50331649 Read Bool
+
+KeyGimbalConnection              67108865 Read       Bool
+KeyGimbalESCFirmwareVersion      67108866 Read       -
+KeyGimbalFirmwareVersion         67108867 Read       -
+KeyGimbalWorkMode                67108868 Read|Write Uint64
+KeyGimbalControlMode             67108869 Read|Write Uint64
+KeyGimbalResetPosition           67108870 Action     Uint64
+KeyGimbalResetPositionState      67108871 Read       -
+KeyGimbalCalibration             67108872 Action     -
+KeyGimbalSpeedRotation           67108873 Action     GimbalSpeedRotation
+KeyGimbalSpeedRotationEnabled    67108874 Write      Bool
+KeyGimbalAngleIncrementRotation  67108875 Action     GimbalAngleRotation
+KeyGimbalAngleFrontYawRotation   67108876 Action     GimbalAngleRotation
+KeyGimbalAngleFrontPitchRotation 67108877 Action     GimbalAngleRotation
+KeyGimbalAttitude                67108878 Read       GimbalAttitude
+KeyGimbalAutoCalibrate           67108879 Action     -
+KeyGimbalCalibrationStatus       67108880 Read       -
+KeyGimbalCalibrationProgress     67108881 Read       -
+KeyGimbalOpenAttitudeUpdates     67108882 Action     Void
+KeyGimbalCloseAttitudeUpdates    67108883 Action     Void
+KeyGimbalGetLinkAck              83886092 Read       -
+
+KeyVisionFirmwareVersion             100663297 Read       -
+KeyVisionTrackingAutoLockTarget      100663298 Read|Write -
+KeyVisionARParameters                100663299 Read       -
+KeyVisionARTagEnabled                100663300 Read       -
+KeyVisionDebugRect                   100663301 Read       -
+KeyVisionLaserPosition               100663302 Read       -
+KeyVisionDetectionEnable             100663303 Read|Write -
+KeyVisionMarkerRunningStatus         100663304 Read       -
+KeyVisionTrackingRunningStatus       100663305 Read       -
+KeyVisionAimbotRunningStatus         100663306 Read       -
+KeyVisionHeadAndShoulderStatus       100663307 Read       -
+KeyVisionHumanDetectionRunningStatus 100663308 Read       -
+KeyVisionUserConfirm                 100663309 Action     -
+KeyVisionUserCancel                  100663310 Action     -
+KeyVisionUserTrackingRect            100663311 Write      -
+KeyVisionTrackingDistance            100663312 Write      -
+KeyVisionLineColor                   100663313 Write      -
+KeyVisionMarkerColor                 100663314 Write      -
+KeyVisionMarkerAdvanceStatus         100663315 Read       -
+
+KeyPerceptionFirmwareVersion 184549377 Read       -
+KeyPerceptionMarkerEnable    184549378 Read|Write -
+KeyPerceptionMarkerResult    184549379 Read       -
+
+KeyESCFirmwareVersion1 201326593 Read -
+KeyESCFirmwareVersion2 201326594 Read -
+KeyESCFirmwareVersion3 201326595 Read -
+KeyESCFirmwareVersion4 201326596 Read -
+KeyESCMotorInfomation1 201326597 Read MotorInfo
+KeyESCMotorInfomation2 201326598 Read MotorInfo
+KeyESCMotorInfomation3 201326599 Read MotorInfo
+KeyESCMotorInfomation4 201326600 Read MotorInfo
+
+KeyWiFiLinkFirmwareVersion         134217729 Read       -
+KeyWiFiLinkDebugInfo               134217730 Read       -
+KeyWiFiLinkMode                    134217731 Read       -
+KeyWiFiLinkSSID                    134217732 Read|Write -
+KeyWiFiLinkPassword                134217733 Read|Write -
+KeyWiFiLinkAvailableChannelNumbers 134217734 Read       -
+KeyWiFiLinkCurrentChannelNumber    134217735 Read|Write -
+KeyWiFiLinkSNR                     134217736 Read       -
+KeyWiFiLinkSNRPushEnabled          134217737 Write      -
+KeyWiFiLinkReboot                  134217738 Action     -
+KeyWiFiLinkChannelSelectionMode    134217739 Read|Write -
+KeyWiFiLinkInterference            134217740 Read       -
+KeyWiFiLinkDeleteNetworkConfig     134217741 Action     -
+
+KeySDRLinkSNR                  268435457 Read       -
+KeySDRLinkBandwidth            268435458 Read|Write -
+KeySDRLinkChannelSelectionMode 268435459 Read|Write -
+KeySDRLinkCurrentFreqPoint     268435460 Read|Write -
+KeySDRLinkCurrentFreqBand      268435461 Read|Write -
+KeySDRLinkIsDualFreqSupported  268435462 Read       -
+KeySDRLinkUpdateConfigs        268435463 Action     -
+
+KeyAirLinkConnection         117440513 Read  Bool
+KeyAirLinkSignalQuality      117440514 Read  Uint64
+KeyAirLinkCountryCode        117440515 Write -
+KeyAirLinkCountryCodeUpdated 117440516 Read  -
+
+KeyArmorFirmwareVersion1 150994945 Read   -
+KeyArmorFirmwareVersion2 150994946 Read   -
+KeyArmorFirmwareVersion3 150994947 Read   -
+KeyArmorFirmwareVersion4 150994948 Read   -
+KeyArmorFirmwareVersion5 150994949 Read   -
+KeyArmorFirmwareVersion6 150994950 Read   -
+KeyArmorUnderAttack      150994951 Read   ArmorHit
+KeyArmorEnterResetID     150994952 Action Void
+KeyArmorCancelResetID    150994953 Action Void
+KeyArmorSkipCurrentID    150994954 Action Void
+KeyArmorResetStatus      150994955 Read   ArmorResetStatus
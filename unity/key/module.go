@@ -0,0 +1,84 @@
+package key
+
+import "fmt"
+
+// Module is the robot module (subsystem) a key belongs to. It is derived from
+// the upper byte of the key sub-type.
+type Module uint8
+
+const (
+	ModuleProduct Module = iota
+	ModuleCamera
+	ModuleMainController
+	ModuleRemoteController
+	ModuleGimbal
+	ModuleRobomasterSystem
+	ModuleVision
+	ModuleAirLink
+	ModuleWiFiLink
+	ModuleArmor
+	ModuleWaterGun
+	ModulePerception
+	ModuleESC
+	ModuleBattery
+	ModuleGamePad
+	ModuleClaw
+	ModuleSDRLink
+	ModuleArm
+	ModuleInfraredGun
+	ModuleTOF
+	ModuleServo
+	ModuleSensorAdapter
+)
+
+// String returns the string representation of the Module.
+func (m Module) String() string {
+	switch m {
+	case ModuleProduct:
+		return "Product"
+	case ModuleCamera:
+		return "Camera"
+	case ModuleMainController:
+		return "MainController"
+	case ModuleRemoteController:
+		return "RemoteController"
+	case ModuleGimbal:
+		return "Gimbal"
+	case ModuleRobomasterSystem:
+		return "RobomasterSystem"
+	case ModuleVision:
+		return "Vision"
+	case ModuleAirLink:
+		return "AirLink"
+	case ModuleWiFiLink:
+		return "WiFiLink"
+	case ModuleArmor:
+		return "Armor"
+	case ModuleWaterGun:
+		return "WaterGun"
+	case ModulePerception:
+		return "Perception"
+	case ModuleESC:
+		return "ESC"
+	case ModuleBattery:
+		return "Battery"
+	case ModuleGamePad:
+		return "GamePad"
+	case ModuleClaw:
+		return "Claw"
+	case ModuleSDRLink:
+		return "SDRLink"
+	case ModuleArm:
+		return "Arm"
+	case ModuleInfraredGun:
+		return "InfraredGun"
+	case ModuleTOF:
+		return "TOF"
+	case ModuleServo:
+		return "Servo"
+	case ModuleSensorAdapter:
+		return "SensorAdapter"
+	}
+
+	return fmt.Sprintf("Unknown(%d)", uint8(m))
+}
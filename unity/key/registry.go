@@ -0,0 +1,146 @@
+package key
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// registryMutex protects the key registry and the result value of all
+	// keys as keys can be registered and changed at runtime.
+	registryMutex sync.RWMutex
+
+	keyByName = make(map[string]*Key, numKeys)
+)
+
+// Register registers a new key with the given name, sub-type, access type
+// and result value prototype (a pointer to a value of the result type, for
+// example &value.Bool{}, or nil if the type is unknown). The new key can be
+// used like any of the predefined keys. It returns an error if the name or
+// sub-type are already registered.
+func Register(name string, subType uint32, accessType AccessType,
+	valueProto any) (*Key, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("key name must be non-empty")
+	}
+
+	if err := validateValueProto(valueProto); err != nil {
+		return nil, err
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if k, ok := keyByName[name]; ok {
+		return nil, fmt.Errorf("key name %q already registered (sub-type %d)",
+			name, k.subType)
+	}
+
+	if k, ok := keyBySubType[subType]; ok {
+		return nil, fmt.Errorf("key sub-type %d already registered (key %s)",
+			subType, k.name)
+	}
+
+	return newKey(name, subType, accessType, valueProto), nil
+}
+
+// SetResultValue overrides the result value type of this key with the type of
+// the given prototype (a pointer to a value of the result type, for example
+// &value.Bool{}). This can be used to provide a type for keys with an unknown
+// result value or to correct an existing type.
+func (k *Key) SetResultValue(valueProto any) error {
+	if valueProto == nil {
+		return fmt.Errorf("result value prototype must be non-nil")
+	}
+
+	if err := validateValueProto(valueProto); err != nil {
+		return err
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	k.resultValue = valueProto
+
+	return nil
+}
+
+// All returns all known keys sorted by sub-type.
+func All() []*Key {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	keys := make([]*Key, 0, len(keyBySubType))
+	for _, k := range keyBySubType {
+		keys = append(keys, k)
+	}
+
+	sortKeys(keys)
+
+	return keys
+}
+
+// FromName returns the Key with the given name (for example,
+// "KeyGimbalAttitude"). It returns an error in case there is no such key.
+func FromName(name string) (*Key, error) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	k, ok := keyByName[name]
+	if !ok {
+		return nil, fmt.Errorf("name does not match any key: %q", name)
+	}
+
+	return k, nil
+}
+
+// ByModule returns all keys that belong to the given module sorted by
+// sub-type.
+func ByModule(m Module) []*Key {
+	return Filter(func(k *Key) bool {
+		return k.Module() == m
+	})
+}
+
+// ByAccessType returns all keys that allow all the given access types (for
+// example, AccessTypeRead|AccessTypeWrite) sorted by sub-type.
+func ByAccessType(accessType AccessType) []*Key {
+	return Filter(func(k *Key) bool {
+		return k.AccessType()&accessType == accessType
+	})
+}
+
+// Filter returns all keys for which the given function returns true sorted by
+// sub-type.
+func Filter(f func(k *Key) bool) []*Key {
+	var keys []*Key
+	for _, k := range All() {
+		if f(k) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Module returns the module this key belongs to.
+func (k *Key) Module() Module {
+	return Module(k.SubType() >> 24)
+}
+
+func validateValueProto(valueProto any) error {
+	if valueProto != nil && reflect.ValueOf(valueProto).Kind() != reflect.Ptr {
+		return fmt.Errorf("result value prototype must be a pointer")
+	}
+
+	return nil
+}
+
+func sortKeys(keys []*Key) {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].subType < keys[j].subType
+	})
+}
@@ -0,0 +1,111 @@
+package key
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	keys := All()
+	assert.GreaterOrEqual(t, len(keys), numKeys)
+
+	for i := 1; i < len(keys); i++ {
+		assert.Less(t, keys[i-1].SubType(), keys[i].SubType())
+	}
+}
+
+func TestFromName(t *testing.T) {
+	k, err := FromName("KeyGimbalAttitude")
+	assert.NoError(t, err)
+	assert.Equal(t, KeyGimbalAttitude, k)
+
+	_, err = FromName("KeyDoesNotExist")
+	assert.Error(t, err)
+}
+
+func TestByModule(t *testing.T) {
+	keys := ByModule(ModuleArmor)
+	assert.Len(t, keys, 11)
+
+	for _, k := range keys {
+		assert.Equal(t, ModuleArmor, k.Module())
+	}
+
+	assert.Equal(t, ModuleGimbal, KeyGimbalAttitude.Module())
+	assert.Equal(t, "Gimbal", KeyGimbalAttitude.Module().String())
+}
+
+func TestByAccessType(t *testing.T) {
+	keys := ByAccessType(AccessTypeRead | AccessTypeWrite)
+	assert.NotEmpty(t, keys)
+
+	for _, k := range keys {
+		assert.NotZero(t, k.AccessType()&AccessTypeRead)
+		assert.NotZero(t, k.AccessType()&AccessTypeWrite)
+	}
+
+	assert.Contains(t, keys, KeyGimbalWorkMode)
+	assert.NotContains(t, keys, KeyGimbalAttitude)
+}
+
+func TestRegister(t *testing.T) {
+	k, err := Register("KeyTestRegister", 0xff000001, AccessTypeRead,
+		&value.Bool{})
+	assert.NoError(t, err)
+	assert.Equal(t, "KeyTestRegister", k.String())
+	assert.Equal(t, Module(0xff), k.Module())
+	assert.Equal(t, &value.Bool{}, k.ResultValue())
+
+	k2, err := FromSubType(0xff000001)
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	k2, err = FromName("KeyTestRegister")
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	assert.Contains(t, All(), k)
+}
+
+func TestRegister_Conflicts(t *testing.T) {
+	_, err := Register("KeyGimbalAttitude", 0xff000002, AccessTypeRead, nil)
+	assert.Error(t, err)
+
+	_, err = Register("KeyTestConflict", KeyGimbalAttitude.SubType(),
+		AccessTypeRead, nil)
+	assert.Error(t, err)
+
+	_, err = Register("", 0xff000002, AccessTypeRead, nil)
+	assert.Error(t, err)
+
+	_, err = Register("KeyTestConflict", 0xff000002, AccessTypeRead,
+		value.Bool{})
+	assert.Error(t, err)
+}
+
+func TestSetResultValue(t *testing.T) {
+	k, err := Register("KeyTestSetResultValue", 0xff000003, AccessTypeRead,
+		nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", k.ValueTypeName())
+	assert.Panics(t, func() { k.ResultValue() })
+
+	err = k.SetResultValue(&value.Uint64{})
+	assert.NoError(t, err)
+	assert.Equal(t, "Uint64", k.ValueTypeName())
+	assert.Equal(t, &value.Uint64{}, k.ResultValue())
+
+	assert.Error(t, k.SetResultValue(nil))
+	assert.Error(t, k.SetResultValue(value.Uint64{}))
+}
+
+func TestValueType(t *testing.T) {
+	assert.Equal(t, reflect.TypeOf(&value.GimbalAttitude{}),
+		KeyGimbalAttitude.ValueType())
+	assert.Equal(t, reflect.TypeOf(json.RawMessage(nil)),
+		KeyProductType.ValueType())
+}
@@ -0,0 +1,61 @@
+package key
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Ref is a reference to a registered key that can be used in configuration
+// structs. It is marshaled as the key name and unmarshaled to the registered
+// key itself, so Ref.Key can be compared with and used like any predefined
+// key. A Ref with a nil Key is the zero value.
+//
+// Key itself only implements encoding.TextMarshaler. Unmarshaling into a Key
+// would fill a copy that is not the registered key (and would overwrite the
+// registered key when given a pointer to it), so keys are unmarshaled through
+// Ref instead.
+type Ref struct {
+	*Key
+}
+
+// MarshalText implements encoding.TextMarshaler. Keys are marshaled as their
+// names. Use Ref to unmarshal them.
+func (k *Key) MarshalText() ([]byte, error) {
+	if k == nil {
+		return nil, fmt.Errorf("can not marshal nil key")
+	}
+
+	return []byte(k.name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts key names
+// (for example, "KeyGimbalAttitude") and numeric sub-types (for example,
+// "67108878" or "0x400000e"). See Parse.
+func (r *Ref) UnmarshalText(text []byte) error {
+	k, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+
+	r.Key = k
+
+	return nil
+}
+
+// Parse returns the registered key with the given name or numeric sub-type
+// (decimal or prefixed hexadecimal, octal or binary).
+func Parse(s string) (*Key, error) {
+	s = strings.TrimSpace(s)
+
+	if k, err := FromName(s); err == nil {
+		return k, nil
+	}
+
+	subType, err := strconv.ParseUint(s, 0, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %q", s)
+	}
+
+	return FromSubType(uint32(subType))
+}
@@ -0,0 +1,77 @@
+package key
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/brunoga/unitybridge/unity/result/value"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey_TextRoundTrip(t *testing.T) {
+	type config struct {
+		Key    Ref        `json:"key"`
+		Access AccessType `json:"access"`
+	}
+
+	data, err := json.Marshal(config{Ref{KeyGimbalAttitude},
+		AccessTypeRead | AccessTypeWrite})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"key":"KeyGimbalAttitude","access":"Read|Write"}`,
+		string(data))
+
+	var c config
+	err = json.Unmarshal(data, &c)
+	assert.NoError(t, err)
+	assert.Same(t, KeyGimbalAttitude, c.Key.Key)
+	assert.Equal(t, AccessType(AccessTypeRead|AccessTypeWrite), c.Access)
+}
+
+func TestKey_UnmarshalText_SubType(t *testing.T) {
+	for _, text := range []string{
+		fmt.Sprint(KeyGimbalAttitude.SubType()),
+		fmt.Sprintf("0x%x", KeyGimbalAttitude.SubType()),
+	} {
+		var r Ref
+		assert.NoError(t, r.UnmarshalText([]byte(text)))
+		assert.Same(t, KeyGimbalAttitude, r.Key)
+	}
+}
+
+func TestKey_UnmarshalText_Invalid(t *testing.T) {
+	var r Ref
+	assert.Error(t, r.UnmarshalText([]byte("KeyDoesNotExist")))
+	assert.Error(t, r.UnmarshalText([]byte("0xffffffff")))
+	assert.Nil(t, r.Key)
+}
+
+func TestKey_UnmarshalText_RegisteredKey(t *testing.T) {
+	k, err := Register("KeyTestText", 0xff000004, AccessTypeRead, nil)
+	assert.NoError(t, err)
+
+	var r Ref
+	assert.NoError(t, r.UnmarshalText([]byte("KeyTestText")))
+	assert.Same(t, k, r.Key)
+
+	// Changes to the registered key are seen through the reference.
+	assert.NoError(t, k.SetResultValue(&value.Uint64{}))
+	assert.Equal(t, "Uint64", r.ValueTypeName())
+}
+
+func TestAccessType_Text(t *testing.T) {
+	for _, a := range []AccessType{AccessTypeNone, AccessTypeRead,
+		AccessTypeRead | AccessTypeWrite | AccessTypeAction} {
+		text, err := a.MarshalText()
+		assert.NoError(t, err)
+
+		var parsed AccessType
+		assert.NoError(t, parsed.UnmarshalText(text))
+		assert.Equal(t, a, parsed)
+	}
+
+	var a AccessType
+	assert.NoError(t, a.UnmarshalText([]byte("3")))
+	assert.Equal(t, AccessType(AccessTypeRead|AccessTypeWrite), a)
+	assert.Error(t, a.UnmarshalText([]byte("Execute")))
+}
@@ -25,13 +25,14 @@ type jsonResult struct {
 	Value json.RawMessage // defer decoding value until we know the type
 }
 
-// New creates a new Result with the given parameters.
+// New creates a new Result with the given parameters. The value must be of
+// the key result value type or a json.RawMessage if the key value type is
+// unknown.
 func New(key *key.Key, tag uint64, errorCode int32, errorDesc string,
 	value any) *Result {
-	if reflect.TypeOf(key.ResultValue()) != reflect.TypeOf(value) {
+	if key.ValueType() != reflect.TypeOf(value) {
 		panic(fmt.Sprintf("result value type (%s) does not match key %s value "+
-			"type (%s)", reflect.TypeOf(value), key, reflect.TypeOf(
-			key.ResultValue())))
+			"type (%s)", reflect.TypeOf(value), key, key.ValueType()))
 	}
 
 	return &Result{
@@ -86,7 +87,8 @@ func (r *Result) ErrorDesc() string {
 	return r.errorDesc
 }
 
-// Value returns the value associated with this result.
+// Value returns the value associated with this result. For keys with an
+// unknown value type, the value is the undecoded json.RawMessage.
 func (r *Result) Value() any {
 	return r.value
 }
@@ -115,10 +117,17 @@ func (r *Result) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	value := key.ResultValue()
-	err = json.Unmarshal(jr.Value, &value)
-	if err != nil {
-		return err
+	// Get the value type only once as it might be registered concurrently.
+	var value any
+	if valueType := key.ValueType(); valueType == reflect.TypeOf(jr.Value) {
+		// Keep the raw value around so it can still be inspected.
+		value = jr.Value
+	} else {
+		value = reflect.New(valueType.Elem()).Interface()
+		err = json.Unmarshal(jr.Value, &value)
+		if err != nil {
+			return err
+		}
 	}
 
 	errorDesc := ""
@@ -136,10 +145,10 @@ func (r *Result) UnmarshalJSON(data []byte) error {
 }
 
 func (r *Result) MarshalJSON() ([]byte, error) {
-	if reflect.TypeOf(r.Key().ResultValue()) != reflect.TypeOf(r.value) {
+	if r.key.ValueType() != reflect.TypeOf(r.value) {
 		return nil, fmt.Errorf("result value type (%s) does not match key %s "+
 			"value type (%s)", reflect.TypeOf(r.value), r.Key(),
-			reflect.TypeOf(r.Key().ResultValue()))
+			r.key.ValueType())
 	}
 
 	value, err := json.Marshal(r.value)
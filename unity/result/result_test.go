@@ -1,6 +1,7 @@
 package result
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -67,3 +68,44 @@ func TestNewFromJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFromJSON_RegisteredKey(t *testing.T) {
+	k, err := key.Register("KeyTestResultRegistered", 0xfe000001,
+		key.AccessTypeRead, &value.Uint64{})
+	if err != nil {
+		t.Fatalf("key.Register() error = %v", err)
+	}
+
+	want := &Result{
+		key:   k,
+		tag:   1,
+		value: &value.Uint64{Value: 42},
+	}
+
+	got := NewFromJSON([]byte(`{"key":4261412865,"tag":1,"value":{"value":42}}`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewFromJSON() = %v, want %v", got, want)
+	}
+}
+
+func TestNewFromJSON_UnknownValueType(t *testing.T) {
+	want := &Result{
+		key:   key.KeyProductType,
+		tag:   1,
+		value: json.RawMessage(`{"value":3}`),
+	}
+
+	got := NewFromJSON([]byte(`{"key":2,"tag":1,"value":{"value":3}}`))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewFromJSON() = %v, want %v", got, want)
+	}
+
+	data, err := got.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	if string(data) != `{"Key":2,"Tag":1,"Error":0,"Value":{"value":3}}` {
+		t.Errorf("MarshalJSON() = %s", data)
+	}
+}
@@ -0,0 +1,7 @@
+package value
+
+// ArmorHit is a hit detected by one of the robot armors.
+type ArmorHit struct {
+	ArmorID uint8 `json:"armorId"`
+	Type    uint8 `json:"type"`
+}
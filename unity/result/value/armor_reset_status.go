@@ -0,0 +1,8 @@
+package value
+
+// ArmorResetStatus is the state of an armor ID assignment and the ID that is
+// currently being assigned.
+type ArmorResetStatus struct {
+	CurrentID uint8 `json:"currentId"`
+	State     uint8 `json:"state"`
+}
@@ -0,0 +1,8 @@
+package value
+
+// AttitudeInfo is the attitude of the robot, in degrees.
+type AttitudeInfo struct {
+	Yaw   float32 `json:"yaw"`
+	Pitch float32 `json:"pitch"`
+	Roll  float32 `json:"roll"`
+}
@@ -0,0 +1,8 @@
+package value
+
+// ChassisSpeed is the chassis body velocity.
+type ChassisSpeed struct {
+	X float32 `json:"x"` // Forward speed, in m/s.
+	Y float32 `json:"y"` // Lateral speed (right is positive), in m/s.
+	Z float32 `json:"z"` // Rotation speed (clockwise is positive), in degrees/s.
+}
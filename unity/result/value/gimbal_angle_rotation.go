@@ -0,0 +1,9 @@
+package value
+
+// GimbalAngleRotation is a gimbal rotation to (or by) the given angles, in
+// degrees, to be completed in the given time, in milliseconds.
+type GimbalAngleRotation struct {
+	Pitch float32 `json:"pitch"`
+	Yaw   float32 `json:"yaw"`
+	Time  uint16  `json:"time"`
+}
@@ -0,0 +1,8 @@
+package value
+
+// GimbalSpeedRotation is the rotation speed of the gimbal, in degrees/s.
+type GimbalSpeedRotation struct {
+	Pitch float32 `json:"pitch"`
+	Yaw   float32 `json:"yaw"`
+	Roll  float32 `json:"roll"`
+}
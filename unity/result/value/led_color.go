@@ -0,0 +1,8 @@
+package value
+
+// LEDColor is an RGB color for the robot LEDs.
+type LEDColor struct {
+	R uint8 `json:"r"`
+	G uint8 `json:"g"`
+	B uint8 `json:"b"`
+}
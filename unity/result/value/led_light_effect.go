@@ -0,0 +1,14 @@
+package value
+
+// LEDLightEffect is a light effect applied to the robot LEDs selected by the
+// given component mask. T1 and T2 are the effect on and off times, in
+// milliseconds.
+type LEDLightEffect struct {
+	Mask       uint8  `json:"mask"`
+	EffectMode uint8  `json:"effectMode"`
+	R          uint8  `json:"r"`
+	G          uint8  `json:"g"`
+	B          uint8  `json:"b"`
+	T1         uint16 `json:"t1"`
+	T2         uint16 `json:"t2"`
+}
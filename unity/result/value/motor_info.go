@@ -0,0 +1,9 @@
+package value
+
+// MotorInfo is the status of a chassis wheel motor as reported by its ESC.
+type MotorInfo struct {
+	Speed     int16  `json:"speed"` // RPM.
+	Angle     uint16 `json:"angle"`
+	TimeStamp uint32 `json:"timeStamp"`
+	State     uint8  `json:"state"`
+}
@@ -0,0 +1,9 @@
+package value
+
+// RelativePosition is the position of the robot relative to where it was
+// when it was powered on.
+type RelativePosition struct {
+	X float32 `json:"x"` // Meters.
+	Y float32 `json:"y"` // Meters.
+	Z float32 `json:"z"` // Yaw, in degrees.
+}
@@ -0,0 +1,9 @@
+package value
+
+// TaskStatus is the progress report for a task (for example, a chassis
+// position move) started by the robot.
+type TaskStatus struct {
+	TaskID  uint8 `json:"taskId"`
+	Percent uint8 `json:"percent"`
+	Status  uint8 `json:"status"`
+}
@@ -0,0 +1,9 @@
+package value
+
+// WheelSpeed holds the speed, in RPM, of each of the chassis wheels.
+type WheelSpeed struct {
+	FrontRight int16 `json:"frontRight"`
+	FrontLeft  int16 `json:"frontLeft"`
+	RearLeft   int16 `json:"rearLeft"`
+	RearRight  int16 `json:"rearRight"`
+}
@@ -19,12 +19,15 @@ type UnityBridge interface {
 	// AddKeyListener adds a listener for events on the given key. If
 	// immediate is true, the callback will be called immediatelly with any
 	// cached value associated with the key. Returns a token that can be used
-	// to remove the listener later.
+	// to remove the listener later. Keys that only push updates after a
+	// companion action (like KeyGimbalAttitude) have that action performed
+	// automatically when their first listener is added.
 	AddKeyListener(k *key.Key, c result.Callback,
 		immediate bool) (token.Token, error)
 
 	// RemoveKeyListener removes the listener associated with the given token
-	// for events on the given key.
+	// for events on the given key. Push updates enabled by AddKeyListener
+	// are disabled when the last listener for the key is removed.
 	RemoveKeyListener(key *key.Key, token token.Token) error
 
 	// GetKeyValue returns the Unity Bridge value associated with the given
@@ -40,6 +43,8 @@ type UnityBridge interface {
 	GetCachedKeyValue(k *key.Key) (*result.Result, error)
 
 	// SetKeyValue sets the Unity Bridge value associated with the given key.
+	// For keys with an unknown value type, the value must be a
+	// json.RawMessage.
 	SetKeyValue(k *key.Key, value any, c result.Callback) error
 
 	// SetKeyValueSync sets the Unity Bridge value associated with the given